@@ -0,0 +1,200 @@
+package config
+
+/*
+ * Module Dependencies
+ */
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+/*
+ * Types
+ */
+
+/*
+ * Constants and Package Scope Variables
+ */
+
+const TAG_NAME string = "config"
+
+/*
+ * Package Private Functions
+ */
+
+func joinKey(parentKey string, key string) string {
+	if parentKey == "" {
+		return key
+	}
+	return parentKey + "." + key
+}
+
+// assign sets value of the option of key to field, converting between
+// numeric types and building slices element by element. Values of other
+// types than field are reported as TypeMismatchError.
+func assign(key string, field reflect.Value, value interface{}) error {
+	val := reflect.ValueOf(value)
+	if val.Type().AssignableTo(field.Type()) {
+		field.Set(val)
+		return nil
+	}
+	mismatchErr := &TypeMismatchError{
+		Key:  key,
+		Want: field.Type().String(),
+		Got:  fmt.Sprintf("%T", value),
+	}
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if _, ok := toFloat64(value); !ok {
+			return mismatchErr
+		}
+		integer64, err := toInt64(value)
+		if err != nil {
+			return err
+		}
+		if field.OverflowInt(integer64) {
+			return errors.New(fmt.Sprintf("Value of option \"%v\" %v overflows %v.", key, integer64, field.Type()))
+		}
+		field.SetInt(integer64)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if _, ok := toFloat64(value); !ok {
+			return mismatchErr
+		}
+		integer64, err := toInt64(value)
+		if err != nil {
+			return err
+		}
+		if integer64 < 0 || field.OverflowUint(uint64(integer64)) {
+			return errors.New(fmt.Sprintf("Value of option \"%v\" %v overflows %v.", key, integer64, field.Type()))
+		}
+		field.SetUint(uint64(integer64))
+	case reflect.Float32, reflect.Float64:
+		flt64, ok := toFloat64(value)
+		if !ok {
+			return mismatchErr
+		}
+		field.SetFloat(flt64)
+	case reflect.Slice:
+		ary, ok := value.([]interface{})
+		if !ok {
+			return mismatchErr
+		}
+		slice := reflect.MakeSlice(field.Type(), len(ary), len(ary))
+		for index, elem := range ary {
+			if elem == nil {
+				continue
+			}
+			if err := assign(fmt.Sprintf("%v[%v]", key, index), slice.Index(index), elem); err != nil {
+				return err
+			}
+		}
+		field.Set(slice)
+	case reflect.Map:
+		mp, ok := value.(map[string]interface{})
+		if !ok || field.Type().Key().Kind() != reflect.String {
+			return mismatchErr
+		}
+		fieldMap := reflect.MakeMapWithSize(field.Type(), len(mp))
+		for mapKey, mapValue := range mp {
+			if mapValue == nil {
+				continue
+			}
+			elem := reflect.New(field.Type().Elem()).Elem()
+			if err := assign(key+"."+mapKey, elem, mapValue); err != nil {
+				return err
+			}
+			fieldMap.SetMapIndex(reflect.ValueOf(mapKey).Convert(field.Type().Key()), elem)
+		}
+		field.Set(fieldMap)
+	default:
+		return mismatchErr
+	}
+	return nil
+}
+
+func (conf Config) bind(structValue reflect.Value, parentKey string, errs *ValidationErrors) {
+	structType := structValue.Type()
+	for index := 0; index < structType.NumField(); index++ {
+		fieldType := structType.Field(index)
+		tag := fieldType.Tag.Get(TAG_NAME)
+		if tag == "" || tag == "-" || fieldType.PkgPath != "" {
+			continue
+		}
+		key := joinKey(parentKey, tag)
+		field := structValue.Field(index)
+
+		opt := conf.findOptByKey(key)
+		if opt == nil {
+			errs.add(key, fmt.Errorf("%w: Option \"%v\" for field %v", ErrKeyNotFound, key, fieldType.Name))
+			continue
+		}
+		if opt.ValueType == "object" && field.Kind() == reflect.Struct {
+			conf.bind(field, key, errs)
+			continue
+		}
+		if opt.ValueType == "array<object>" && field.Kind() == reflect.Slice &&
+			field.Type().Elem().Kind() == reflect.Struct {
+			elemConfs, err := conf.GetObjectArray(key)
+			// Options without any value leave the field as it is.
+			if err != nil {
+				continue
+			}
+			slice := reflect.MakeSlice(field.Type(), len(elemConfs), len(elemConfs))
+			for index, elemConf := range elemConfs {
+				var elemErrs ValidationErrors
+				elemConf.bind(slice.Index(index), "", &elemErrs)
+				errs.addPrefixed(fmt.Sprintf("%v[%v]", key, index), elemErrs.err())
+			}
+			field.Set(slice)
+			continue
+		}
+		if opt.ValueType == "map<object>" && field.Kind() == reflect.Map &&
+			field.Type().Key().Kind() == reflect.String && field.Type().Elem().Kind() == reflect.Struct {
+			elemConfs, err := conf.GetObjectMap(key)
+			// Options without any value leave the field as it is.
+			if err != nil {
+				continue
+			}
+			fieldMap := reflect.MakeMapWithSize(field.Type(), len(elemConfs))
+			for mapKey, elemConf := range elemConfs {
+				elem := reflect.New(field.Type().Elem()).Elem()
+				var elemErrs ValidationErrors
+				elemConf.bind(elem, "", &elemErrs)
+				errs.addPrefixed(joinKey(key, mapKey), elemErrs.err())
+				fieldMap.SetMapIndex(reflect.ValueOf(mapKey).Convert(field.Type().Key()), elem)
+			}
+			field.Set(fieldMap)
+			continue
+		}
+		value, err := opt.GetValue()
+		// Options without any value leave the field as it is.
+		if err != nil {
+			continue
+		}
+		errs.add(key, assign(key, field, value))
+	}
+}
+
+/*
+ * Public Functions
+ */
+
+// Unmarshal stores option values in the struct pointed to by v.
+// Fields are mapped to options by the key in their `config:"key"` tag, and
+// a struct field tagged with the key of an object option is filled with
+// its child options, whose tags are relative to the object key. A slice or
+// a map of structs is filled likewise from each element of an array<object>
+// or a map<object> option.
+// Fields of all options are set before ValidationErrors reporting every
+// failure are returned.
+func (conf Config) Unmarshal(v interface{}) error {
+	ptr := reflect.ValueOf(v)
+	if ptr.Kind() != reflect.Ptr || ptr.IsNil() || ptr.Elem().Kind() != reflect.Struct {
+		return errors.New(fmt.Sprintf("Unmarshal requires a non-nil pointer to struct. But specified value is %T.", v))
+	}
+	var errs ValidationErrors
+	conf.bind(ptr.Elem(), "", &errs)
+	return errs.err()
+}
@@ -0,0 +1,151 @@
+package config
+
+/*
+ * Module Dependencies
+ */
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mozzzzy/config/json/configOption"
+	"github.com/mozzzzy/testUtil"
+)
+
+/*
+ * Types
+ */
+
+type objectSettings struct {
+	Array   []string `config:"array"`
+	Float64 float64  `config:"float64"`
+	Int     int      `config:"int"`
+	String  string   `config:"string"`
+}
+
+type allSettings struct {
+	Array   []string       `config:"array"`
+	Float64 float32        `config:"float64"`
+	Int     int            `config:"int"`
+	String  string         `config:"string"`
+	Object  objectSettings `config:"object"`
+	Ignored string
+}
+
+/*
+ * Constants and Package Scope Variables
+ */
+
+/*
+ * Functions
+ */
+
+func newAllInOneConfig(t *testing.T) Config {
+	var conf Config
+	addOptionErr := conf.AddOptions([]configOption.Option{
+		{Key: "array", ValueType: "array"},
+		{Key: "float64", ValueType: "float64"},
+		{Key: "int", ValueType: "int"},
+		{Key: "string", ValueType: "string"},
+		{Key: "object", ValueType: "object"},
+		{Key: "object.array", ValueType: "array"},
+		{Key: "object.float64", ValueType: "float64"},
+		{Key: "object.int", ValueType: "int"},
+		{Key: "object.string", ValueType: "string"},
+	})
+	testUtil.NoError(t, addOptionErr)
+
+	parseErr := conf.Parse(ALL_IN_ONE_JSON)
+	testUtil.NoError(t, parseErr)
+	return conf
+}
+
+func TestUnmarshal(t *testing.T) {
+	t.Run("valid multi", func(t *testing.T) {
+		conf := newAllInOneConfig(t)
+
+		var settings allSettings
+		unmarshalErr := conf.Unmarshal(&settings)
+		testUtil.NoError(t, unmarshalErr)
+
+		testUtil.Match(t, allSettings{
+			Array:   []string{"some", "value"},
+			Float64: 1.2,
+			Int:     10,
+			String:  "some value",
+			Object: objectSettings{
+				Array:   []string{"some", "value", "in", "object"},
+				Float64: 2.2,
+				Int:     20,
+				String:  "some value in object",
+			},
+		}, settings)
+	})
+
+	t.Run("default value", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:          "int",
+				ValueType:    "int",
+				DefaultValue: 10,
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		var settings struct {
+			Int int64 `config:"int"`
+		}
+		unmarshalErr := conf.Unmarshal(&settings)
+		testUtil.NoError(t, unmarshalErr)
+		testUtil.Match(t, int64(10), settings.Int)
+	})
+
+	t.Run("invalid (report all mismatches)", func(t *testing.T) {
+		conf := newAllInOneConfig(t)
+
+		var settings struct {
+			Array  []int  `config:"array"`
+			Int    int8   `config:"string"`
+			String string `config:"int"`
+			Object struct {
+				Int int `config:"string"`
+			} `config:"object"`
+			NotExist string `config:"not_exist"`
+		}
+		unmarshalErr := conf.Unmarshal(&settings)
+		testUtil.WithError(t, unmarshalErr)
+		var validationErrs ValidationErrors
+		testUtil.Match(t, true, errors.As(unmarshalErr, &validationErrs))
+		testUtil.Match(t, []string{"array", "string", "int", "object.string", "not_exist"}, keysOf(validationErrs))
+
+		var typeMismatchErr *TypeMismatchError
+		testUtil.Match(t, true, errors.As(unmarshalErr, &typeMismatchErr))
+		if typeMismatchErr != nil {
+			testUtil.Match(t, TypeMismatchError{Key: "array[0]", Want: "int", Got: "string"}, *typeMismatchErr)
+		}
+		testUtil.Match(t, true, errors.Is(unmarshalErr, ErrKeyNotFound))
+	})
+
+	t.Run("invalid (overflow)", func(t *testing.T) {
+		conf := newAllInOneConfig(t)
+
+		var settings struct {
+			Int uint8 `config:"int"`
+		}
+		setErr := conf.Set("int", 300)
+		testUtil.NoError(t, setErr)
+		unmarshalErr := conf.Unmarshal(&settings)
+		var validationErrs ValidationErrors
+		testUtil.Match(t, true, errors.As(unmarshalErr, &validationErrs))
+		testUtil.Match(t, []string{"int"}, keysOf(validationErrs))
+	})
+
+	t.Run("invalid (not a pointer to struct)", func(t *testing.T) {
+		conf := newAllInOneConfig(t)
+
+		var settings allSettings
+		unmarshalErr := conf.Unmarshal(settings)
+		testUtil.WithError(t, unmarshalErr)
+	})
+}
@@ -0,0 +1,82 @@
+package config
+
+/*
+ * Module Dependencies
+ */
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+/*
+ * Types
+ */
+
+/*
+ * Constants and Package Scope Variables
+ */
+
+// Units of bytesize options. SI units are powers of 1000 and IEC units are
+// powers of 1024.
+var byteSizeUnits = map[string]int64{
+	"":    1,
+	"b":   1,
+	"k":   1000,
+	"kb":  1000,
+	"kib": 1 << 10,
+	"m":   1000 * 1000,
+	"mb":  1000 * 1000,
+	"mib": 1 << 20,
+	"g":   1000 * 1000 * 1000,
+	"gb":  1000 * 1000 * 1000,
+	"gib": 1 << 30,
+	"t":   1000 * 1000 * 1000 * 1000,
+	"tb":  1000 * 1000 * 1000 * 1000,
+	"tib": 1 << 40,
+	"p":   1000 * 1000 * 1000 * 1000 * 1000,
+	"pb":  1000 * 1000 * 1000 * 1000 * 1000,
+	"pib": 1 << 50,
+}
+
+/*
+ * Package Private Functions
+ */
+
+// parseByteSize parses a size such as "512MiB", "10GB", "1.5KiB" or "1024"
+// into a number of bytes.
+func parseByteSize(str string) (int64, error) {
+	trimmed := strings.TrimSpace(str)
+	numEnd := strings.IndexFunc(trimmed, func(r rune) bool {
+		return !(('0' <= r && r <= '9') || r == '.' || r == '-' || r == '+')
+	})
+	if numEnd < 0 {
+		numEnd = len(trimmed)
+	}
+	num := trimmed[:numEnd]
+	unit, ok := byteSizeUnits[strings.ToLower(strings.TrimSpace(trimmed[numEnd:]))]
+	if num == "" || !ok {
+		return 0, errors.New(fmt.Sprintf("Invalid bytesize value \"%v\".", str))
+	}
+	if strings.HasPrefix(num, "-") {
+		return 0, errors.New(fmt.Sprintf("Bytesize value \"%v\" is negative.", str))
+	}
+	if integer64, err := strconv.ParseInt(num, 10, 64); err == nil {
+		if integer64 > math.MaxInt64/unit || integer64 < math.MinInt64/unit {
+			return 0, errors.New(fmt.Sprintf("Bytesize value \"%v\" overflows int64.", str))
+		}
+		return integer64 * unit, nil
+	}
+	flt64, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, errors.New(fmt.Sprintf("Invalid bytesize value \"%v\".", str))
+	}
+	bytes, err := toInt64(flt64 * float64(unit))
+	if err != nil {
+		return 0, errors.New(fmt.Sprintf("Invalid bytesize value \"%v\". %v", str, err))
+	}
+	return bytes, nil
+}
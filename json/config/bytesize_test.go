@@ -0,0 +1,66 @@
+package config
+
+/*
+ * Module Dependencies
+ */
+
+import (
+	"testing"
+
+	"github.com/mozzzzy/testUtil"
+)
+
+/*
+ * Types
+ */
+
+/*
+ * Constants and Package Scope Variables
+ */
+
+/*
+ * Functions
+ */
+
+func TestParseByteSize(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		for str, expect := range map[string]int64{
+			"1024":    1024,
+			"100B":    100,
+			"10GB":    10 * 1000 * 1000 * 1000,
+			"10 gb":   10 * 1000 * 1000 * 1000,
+			"512MiB":  512 * 1024 * 1024,
+			"1.5KiB":  1536,
+			"8000PiB": 8000 << 50,
+		} {
+			actual, err := parseByteSize(str)
+			testUtil.NoError(t, err)
+			testUtil.Match(t, expect, actual)
+		}
+	})
+
+	t.Run("invalid (no number)", func(t *testing.T) {
+		_, err := parseByteSize("MiB")
+		testUtil.WithError(t, err)
+	})
+
+	t.Run("invalid (unknown unit)", func(t *testing.T) {
+		_, err := parseByteSize("8EiB")
+		testUtil.WithError(t, err)
+	})
+
+	t.Run("invalid (fractional bytes)", func(t *testing.T) {
+		_, err := parseByteSize("1.5B")
+		testUtil.WithError(t, err)
+	})
+
+	t.Run("invalid (overflow)", func(t *testing.T) {
+		_, err := parseByteSize("9000PiB")
+		testUtil.WithError(t, err)
+	})
+
+	t.Run("invalid (negative)", func(t *testing.T) {
+		_, err := parseByteSize("-5MB")
+		testUtil.WithError(t, err)
+	})
+}
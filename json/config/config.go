@@ -8,9 +8,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"io/ioutil"
+	"math"
+	"net/netip"
+	"net/url"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/mozzzzy/config/json/configOption"
 )
@@ -20,7 +27,24 @@ import (
  */
 
 type Config struct {
-	options []configOption.Option
+	options   []configOption.Option
+	envBound  bool
+	envPrefix string
+	flags     map[string]*optionFlag
+	strict    bool
+	// key path -> where its value was read from a file
+	locations map[string]location
+}
+
+// layer is a set of key values being parsed.
+type layer struct {
+	origin configOption.Origin
+	// key path -> position in the file of origin
+	locations map[string]location
+	// Prefix of key paths in locations, e.g. "servers[1]" for an element.
+	prefix string
+	// Unless override is true, setting an option twice is an error.
+	override bool
 }
 
 /*
@@ -41,6 +65,497 @@ func getMaxLen(strs []string) int {
 	return maxLen
 }
 
+// toInt64 converts an integer decoded from a config file into int64.
+// Fractional values and values out of the int64 range are rejected instead
+// of being truncated.
+func toInt64(value interface{}) (int64, error) {
+	switch val := value.(type) {
+	case int:
+		return int64(val), nil
+	case int64:
+		return val, nil
+	case uint64:
+		if val > math.MaxInt64 {
+			return 0, errors.New(fmt.Sprintf("%v overflows int64.", val))
+		}
+		return int64(val), nil
+	case float64:
+		if val != math.Trunc(val) {
+			return 0, errors.New(fmt.Sprintf("%v is not an integer.", val))
+		}
+		// float64(math.MaxInt64) is rounded up to 2^63.
+		if val < math.MinInt64 || val >= math.MaxInt64 {
+			return 0, errors.New(fmt.Sprintf("%v overflows int64.", val))
+		}
+		return int64(val), nil
+	case json.Number:
+		integer64, err := strconv.ParseInt(val.String(), 10, 64)
+		if err == nil {
+			return integer64, nil
+		}
+		if numErr, ok := err.(*strconv.NumError); ok && numErr.Err == strconv.ErrRange {
+			return 0, errors.New(fmt.Sprintf("%v overflows int64.", val))
+		}
+		// e.g. 1e3 or 1.0
+		flt64, err := val.Float64()
+		if err != nil {
+			return 0, errors.New(fmt.Sprintf("%v is not a number.", val))
+		}
+		return toInt64(flt64)
+	}
+	return 0, errors.New(fmt.Sprintf("%T is not a number.", value))
+}
+
+func toFloat64(value interface{}) (float64, bool) {
+	switch val := value.(type) {
+	case float64:
+		return val, true
+	case int:
+		return float64(val), true
+	case int64:
+		return float64(val), true
+	case uint64:
+		return float64(val), true
+	case json.Number:
+		flt64, err := val.Float64()
+		return flt64, err == nil
+	}
+	return 0, false
+}
+
+// integerOf converts an integer in an untyped array or map into int64.
+// Depending on the format, such integers are int, int64 or float64. A float64
+// is accepted only if it is whole and below 2^53, where it is still exact.
+func integerOf(value interface{}) (int64, bool) {
+	switch val := value.(type) {
+	case int:
+		return int64(val), true
+	case int64:
+		return val, true
+	case uint64:
+		if val <= math.MaxInt64 {
+			return int64(val), true
+		}
+	case float64:
+		if val == math.Trunc(val) && math.Abs(val) < 1<<53 {
+			return int64(val), true
+		}
+	}
+	return 0, false
+}
+
+// normalizeNumbers replaces json.Number in values of untyped arrays and maps
+// with float64, as json.Unmarshal does without UseNumber. Integers which need
+// all 64 bits have to be declared as array<int64> or map<int64>.
+func normalizeNumbers(value interface{}) interface{} {
+	switch val := value.(type) {
+	case json.Number:
+		if flt64, err := val.Float64(); err == nil {
+			return flt64
+		}
+	case []interface{}:
+		ary := make([]interface{}, 0, len(val))
+		for _, elem := range val {
+			ary = append(ary, normalizeNumbers(elem))
+		}
+		return ary
+	case map[string]interface{}:
+		mp := make(map[string]interface{}, len(val))
+		for mapKey, mapValue := range val {
+			mp[mapKey] = normalizeNumbers(mapValue)
+		}
+		return mp
+	}
+	return value
+}
+
+// parseString converts a value given as text (e.g. by an environment
+// variable) according to the ValueType of opt.
+func parseString(opt *configOption.Option, str string) (interface{}, error) {
+	if _, ok := configOption.ElementType(opt.ValueType); ok || opt.ValueType == "array" {
+		ary := []interface{}{}
+		decoder := json.NewDecoder(strings.NewReader(str))
+		decoder.UseNumber()
+		if err := decoder.Decode(&ary); err != nil {
+			return nil, errors.New(fmt.Sprintf(
+				"Invalid array value \"%v\". It should be a json array.", str))
+		}
+		return convertValue(opt.Key, opt.ValueType, ary)
+	}
+	if _, ok := configOption.MapValueType(opt.ValueType); ok || opt.ValueType == "map" {
+		mp := map[string]interface{}{}
+		decoder := json.NewDecoder(strings.NewReader(str))
+		decoder.UseNumber()
+		if err := decoder.Decode(&mp); err != nil {
+			return nil, errors.New(fmt.Sprintf(
+				"Invalid map value \"%v\". It should be a json object.", str))
+		}
+		return convertValue(opt.Key, opt.ValueType, mp)
+	}
+	switch opt.ValueType {
+	case "bool":
+		boolean, err := strconv.ParseBool(str)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("Invalid bool value \"%v\".", str))
+		}
+		return boolean, nil
+	case "bytesize":
+		return parseByteSize(str)
+	case "cidr":
+		prefix, err := netip.ParsePrefix(str)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("Invalid cidr value \"%v\".", str))
+		}
+		return prefix, nil
+	case "duration":
+		duration, err := time.ParseDuration(str)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("Invalid duration value \"%v\".", str))
+		}
+		return duration, nil
+	case "float64":
+		flt64, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("Invalid float64 value \"%v\".", str))
+		}
+		return flt64, nil
+	case "hostport":
+		addrPort, err := netip.ParseAddrPort(str)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf(
+				"Invalid hostport value \"%v\". It should be an ip address and a port.", str))
+		}
+		return addrPort, nil
+	case "int":
+		integer, err := strconv.Atoi(str)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("Invalid int value \"%v\".", str))
+		}
+		return integer, nil
+	case "int64":
+		integer64, err := strconv.ParseInt(str, 10, 64)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("Invalid int64 value \"%v\".", str))
+		}
+		return integer64, nil
+	case "ip":
+		addr, err := netip.ParseAddr(str)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("Invalid ip value \"%v\".", str))
+		}
+		return addr, nil
+	case "string":
+		return str, nil
+	case "time":
+		tm, err := time.Parse(time.RFC3339, str)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("Invalid time value \"%v\".", str))
+		}
+		return tm, nil
+	case "url":
+		urlPtr, err := url.Parse(str)
+		if err != nil || urlPtr.Scheme == "" {
+			return nil, errors.New(fmt.Sprintf(
+				"Invalid url value \"%v\". It should be an absolute url.", str))
+		}
+		return urlPtr, nil
+	}
+	return nil, errors.New(fmt.Sprintf(
+		"Option %v of type %v can't be set from a string.", opt.Key, opt.ValueType))
+}
+
+// kindOf names the kind of a decoded value as config files do, e.g.
+// "number" rather than json.Number, for error messages.
+func kindOf(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "bool"
+	case json.Number, float64, int, int64, uint64:
+		return "number"
+	case string:
+		return "string"
+	case time.Time:
+		return "datetime"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// convertValue converts a value decoded from a config file into the Go type
+// stored by options of valueType. key is only used in error messages.
+func convertValue(key string, valueType string, value interface{}) (interface{}, error) {
+	if elemType, ok := configOption.ElementType(valueType); ok {
+		ary, ok := value.([]interface{})
+		if !ok {
+			return nil, &TypeMismatchError{
+				Key:  key,
+				Want: valueType,
+				Got:  kindOf(value),
+			}
+		}
+		elems := make([]interface{}, 0, len(ary))
+		for index, elem := range ary {
+			converted, err := convertValue(fmt.Sprintf("%v[%v]", key, index), elemType, elem)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, converted)
+		}
+		return elems, nil
+	}
+	if mapValueType, ok := configOption.MapValueType(valueType); ok || valueType == "map" {
+		mp, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, &TypeMismatchError{
+				Key:  key,
+				Want: valueType,
+				Got:  kindOf(value),
+			}
+		}
+		if mapValueType == "" {
+			return normalizeNumbers(mp), nil
+		}
+		values := make(map[string]interface{}, len(mp))
+		for mapKey, mapValue := range mp {
+			converted, err := convertValue(key+"."+mapKey, mapValueType, mapValue)
+			if err != nil {
+				return nil, err
+			}
+			values[mapKey] = converted
+		}
+		return values, nil
+	}
+	switch valueType {
+	case "array":
+		ary, ok := value.([]interface{})
+		if !ok {
+			return nil, &TypeMismatchError{
+				Key:  key,
+				Want: valueType,
+				Got:  kindOf(value),
+			}
+		}
+		return normalizeNumbers(ary), nil
+	case "bool":
+		boolean, ok := value.(bool)
+		if !ok {
+			return nil, &TypeMismatchError{
+				Key:  key,
+				Want: valueType,
+				Got:  kindOf(value),
+			}
+		}
+		return boolean, nil
+	case "bytesize":
+		var bytes int64
+		var err error
+		if str, ok := value.(string); ok {
+			bytes, err = parseByteSize(str)
+		} else if _, ok := toFloat64(value); ok {
+			bytes, err = toInt64(value)
+			if err == nil && bytes < 0 {
+				err = errors.New("Bytesize must not be negative.")
+			}
+		} else {
+			return nil, &TypeMismatchError{
+				Key:  key,
+				Want: valueType,
+				Got:  kindOf(value),
+			}
+		}
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf(
+				"Invalid bytesize value for %v \"%v\". %v", key, value, err))
+		}
+		return bytes, nil
+	case "cidr", "hostport", "ip", "url":
+		str, ok := value.(string)
+		if !ok {
+			return nil, &TypeMismatchError{
+				Key:  key,
+				Want: valueType,
+				Got:  kindOf(value),
+			}
+		}
+		converted, err := parseString(&configOption.Option{Key: key, ValueType: valueType}, str)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("Invalid value for %v. %v", key, err))
+		}
+		return converted, nil
+	case "duration":
+		str, ok := value.(string)
+		if !ok {
+			return nil, &TypeMismatchError{
+				Key:  key,
+				Want: valueType,
+				Got:  kindOf(value),
+			}
+		}
+		duration, err := time.ParseDuration(str)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf(
+				"Invalid duration value for %v \"%v\". %v", key, value, err))
+		}
+		return duration, nil
+	case "float64":
+		flt64, ok := toFloat64(value)
+		if !ok {
+			return nil, &TypeMismatchError{
+				Key:  key,
+				Want: valueType,
+				Got:  kindOf(value),
+			}
+		}
+		return flt64, nil
+	case "int":
+		if _, ok := toFloat64(value); !ok {
+			return nil, &TypeMismatchError{
+				Key:  key,
+				Want: valueType,
+				Got:  kindOf(value),
+			}
+		}
+		integer64, err := toInt64(value)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf(
+				"Invalid int value for %v \"%v\". %v", key, value, err))
+		}
+		integer := int(integer64)
+		if int64(integer) != integer64 {
+			return nil, errors.New(fmt.Sprintf(
+				"Invalid int value for %v \"%v\". %v overflows int.", key, value, integer64))
+		}
+		return integer, nil
+	case "int64":
+		if _, ok := toFloat64(value); !ok {
+			return nil, &TypeMismatchError{
+				Key:  key,
+				Want: valueType,
+				Got:  kindOf(value),
+			}
+		}
+		integer64, err := toInt64(value)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf(
+				"Invalid int64 value for %v \"%v\". %v", key, value, err))
+		}
+		return integer64, nil
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return nil, &TypeMismatchError{
+				Key:  key,
+				Want: valueType,
+				Got:  kindOf(value),
+			}
+		}
+		return str, nil
+	case "time":
+		switch val := value.(type) {
+		case time.Time:
+			return val, nil
+		case string:
+			tm, err := time.Parse(time.RFC3339, val)
+			if err != nil {
+				return nil, errors.New(fmt.Sprintf(
+					"Invalid time value for %v \"%v\". It should be RFC 3339.", key, value))
+			}
+			return tm, nil
+		}
+		return nil, &TypeMismatchError{
+			Key:  key,
+			Want: valueType,
+			Got:  kindOf(value),
+		}
+	}
+	return nil, errors.New(fmt.Sprintf(
+		"Value type %v of %v is not supported.", valueType, key))
+}
+
+// setParents marks the object options containing key as set from origin, as
+// parseOneLayer does when it walks into them.
+func (conf *Config) setParents(key string, origin configOption.Origin) error {
+	keyElems := strings.Split(key, ".")
+	for keyCount := 1; keyCount < len(keyElems); keyCount++ {
+		parentOpt := conf.findOptByKey(strings.Join(keyElems[:keyCount], "."))
+		if parentOpt == nil || parentOpt.IsSet() {
+			continue
+		}
+		if err := parentOpt.SetValueFrom(0, origin); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isPattern reports whether key has a wildcard element, e.g.
+// "plugins.*.enabled".
+func isPattern(key string) bool {
+	for _, keyElem := range strings.Split(key, ".") {
+		if keyElem == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// matchKeyElems reports whether the elements of a key match the elements of
+// a pattern, in which "*" matches any element.
+func matchKeyElems(patternElems []string, keyElems []string) bool {
+	if len(patternElems) != len(keyElems) {
+		return false
+	}
+	for index, keyElem := range keyElems {
+		if patternElems[index] != "*" && patternElems[index] != keyElem {
+			return false
+		}
+	}
+	return true
+}
+
+// instantiate adds options for key from the pattern options matching it,
+// e.g. "plugins.auth" and "plugins.auth.enabled" from "plugins.*" and
+// "plugins.*.enabled", and returns the option of key. It returns nil if no
+// pattern matches key.
+func (conf *Config) instantiate(key string) *configOption.Option {
+	keyElems := strings.Split(key, ".")
+	var instances []configOption.Option
+	for _, opt := range conf.options {
+		patternElems := strings.Split(opt.Key, ".")
+		if len(patternElems) < len(keyElems) ||
+			!isPattern(strings.Join(patternElems[:len(keyElems)], ".")) {
+			continue
+		}
+		if !matchKeyElems(patternElems[:len(keyElems)], keyElems) {
+			continue
+		}
+		instance := opt
+		instance.Key = strings.Join(
+			append(append([]string{}, keyElems...), patternElems[len(keyElems):]...), ".")
+		if conf.findOptByKey(instance.Key) == nil {
+			instances = append(instances, instance)
+		}
+	}
+	if len(instances) == 0 {
+		return nil
+	}
+	conf.options = append(conf.options, instances...)
+	// The object option of a wildcard element may be left undeclared.
+	if opt := conf.findOptByKey(key); opt != nil {
+		return opt
+	}
+	conf.options = append(conf.options, configOption.Option{
+		Key:       key,
+		ValueType: "object",
+	})
+	return conf.findOptByKey(key)
+}
+
 func (conf Config) findOptByKey(key string) *configOption.Option {
 	for index := 0; index < len(conf.options); index++ {
 		if conf.options[index].Key == key {
@@ -58,7 +573,21 @@ func getAllKeys(opts *[]configOption.Option) []string {
 	return keys
 }
 
-func (conf *Config) parseOneLayer(kvs map[string]interface{}, parentKey string) error {
+// originOf returns the origin of the value at the key path.
+func (l layer) originOf(path string) configOption.Origin {
+	origin := l.origin
+	if loc, ok := l.locations[joinKey(l.prefix, path)]; ok {
+		origin.Line = loc.pos.Line
+		origin.Column = loc.pos.Column
+	}
+	return origin
+}
+
+// parseOneLayer sets options from one layer of key values and recurses into
+// objects.
+func (conf *Config) parseOneLayer(kvs map[string]interface{}, parentKey string, l layer) error {
+	var errs ValidationErrors
+
 	// Get keys
 	var keys []string
 	for key, _ := range kvs {
@@ -75,80 +604,241 @@ func (conf *Config) parseOneLayer(kvs map[string]interface{}, parentKey string)
 			absolutePath = key
 		}
 		opt := conf.findOptByKey(absolutePath)
+		// Options declared by patterns are added when they are found.
+		if opt == nil {
+			opt = conf.instantiate(absolutePath)
+		}
 		// If matched option is not found, continue.
 		if opt == nil {
 			continue
 		}
-		// If found option has already set, return error.
-		if opt.IsSet() == true {
-			return errors.New(fmt.Sprintf("Duplicate definition of %v", key))
+		// If found option has already set, it is an error.
+		if opt.IsSet() == true && !l.override {
+			errs.add(absolutePath, fmt.Errorf("%w of %v", ErrDuplicate, key))
+			continue
 		}
 		// If found option require value, set value.
 		switch opt.ValueType {
 		case "":
 		case "nil":
-		case "array":
-			ary, ok := kvs[key].([]interface{})
-			if !ok {
-				return errors.New(fmt.Sprintf(
-					"Invalid array value for %v \"%v\".", key, kvs[key]))
-			}
-			if err := opt.SetValue(ary); err != nil {
-				return err
-			}
-		case "float64":
-			flt64, ok := kvs[key].(float64)
-			if !ok {
-				return errors.New(fmt.Sprintf(
-					"Invalid float64 value for %v \"%v\".", key, kvs[key]))
-			}
-			if err := opt.SetValue(flt64); err != nil {
-				return err
-			}
-		case "int":
-			flt64, ok := kvs[key].(float64)
-			if !ok {
-				return errors.New(fmt.Sprintf(
-					"Invalid float64 value for %v \"%v\".", key, kvs[key]))
-			}
-			integer := int(flt64)
-			if err := opt.SetValue(integer); err != nil {
-				return err
-			}
-		case "int64":
-			flt64, ok := kvs[key].(float64)
-			if !ok {
-				return errors.New(fmt.Sprintf(
-					"Invalid float64 value for %v \"%v\".", key, kvs[key]))
-			}
-			integer64 := int64(flt64)
-			if err := opt.SetValue(integer64); err != nil {
-				return err
-			}
 		case "object":
-			if err := opt.SetValue(0); err != nil {
-				return err
+			if err := opt.SetValueFrom(0, l.originOf(absolutePath)); err != nil {
+				errs.add(absolutePath, err)
+				continue
 			}
 			nextKvs, ok := kvs[key].(map[string]interface{})
 			if !ok {
-				return errors.New(fmt.Sprintf(
-					"Invalid object value for %v \"%v\".", key, kvs[key]))
+				errs.add(absolutePath, errors.New(fmt.Sprintf(
+					"Invalid object value for %v \"%v\".", key, kvs[key])))
+				continue
+			}
+			errs.add(absolutePath, conf.parseOneLayer(nextKvs, absolutePath, l))
+		case "array<object>":
+			elems, err := conf.parseObjectArray(key, absolutePath, kvs[key], l)
+			if err != nil {
+				errs.add(absolutePath, err)
+				continue
 			}
-			if err := conf.parseOneLayer(nextKvs, absolutePath); err != nil {
-				return err
+			errs.add(absolutePath, opt.SetValueFrom(elems, l.originOf(absolutePath)))
+		case "map<object>":
+			values, err := conf.parseObjectMap(key, absolutePath, kvs[key], l)
+			if err != nil {
+				errs.add(absolutePath, err)
+				continue
 			}
-		case "string":
-			str, ok := kvs[key].(string)
+			errs.add(absolutePath, opt.SetValueFrom(values, l.originOf(absolutePath)))
+		default:
+			// Report the whole key path, e.g. "servers[1].port" of an element.
+			value, err := convertValue(joinKey(l.prefix, absolutePath), opt.ValueType, kvs[key])
+			if err != nil {
+				errs.add(absolutePath, err)
+				continue
+			}
+			errs.add(absolutePath, opt.SetValueFrom(value, l.originOf(absolutePath)))
+		}
+	}
+	return errs.err()
+}
+
+// parseBytes decodes raw with the decoder registered as format, and parses
+// it. name is the file name reported in positions of failures.
+func (conf *Config) parseBytes(name string, raw []byte, format string) error {
+	keyValues, locations, decodeErr := decode(name, raw, format)
+	if decodeErr != nil {
+		return decodeErr
+	}
+	conf.setLocations(locations)
+	return conf.parseKeyValues(keyValues, layer{
+		origin:    configOption.Origin{Kind: configOption.ORIGIN_FILE, Name: name},
+		locations: locations,
+	})
+}
+
+// parseObject parses an element of an array or a map of objects into a
+// Config holding the child options of absolutePath, so that defaults,
+// required options and validators are applied per element. Keys of the
+// failures are prefixed with elemPath, e.g. "servers[1]".
+func (conf Config) parseObject(elemPath string, absolutePath string, elem interface{}, l layer) (Config, error) {
+	elemKvs, ok := elem.(map[string]interface{})
+	if !ok {
+		return Config{}, &ValidationError{
+			Key: elemPath,
+			Err: errors.New(fmt.Sprintf("Invalid object value \"%v\".", elem)),
+		}
+	}
+	elemConf, err := conf.GetObject(absolutePath)
+	if err != nil {
+		return Config{}, err
+	}
+	var errs ValidationErrors
+	elemLayer := l
+	elemLayer.prefix = joinKey(l.prefix, elemPath)
+	elemLayer.override = false
+	errs.addPrefixed(elemPath, elemConf.parseOneLayer(elemKvs, "", elemLayer))
+	errs.addPrefixed(elemPath, elemConf.Validate())
+	return elemConf, errs.err()
+}
+
+func (conf Config) parseObjectArray(key string, absolutePath string, value interface{}, l layer) ([]interface{}, error) {
+	ary, ok := value.([]interface{})
+	if !ok {
+		return nil, errors.New(fmt.Sprintf(
+			"Invalid array<object> value for %v \"%v\".", key, value))
+	}
+	var errs ValidationErrors
+	elems := make([]interface{}, 0, len(ary))
+	for index, elem := range ary {
+		elemConf, err := conf.parseObject(fmt.Sprintf("%v[%v]", absolutePath, index), absolutePath, elem, l)
+		errs.add("", err)
+		elems = append(elems, elemConf)
+	}
+	return elems, errs.err()
+}
+
+func (conf Config) parseObjectMap(key string, absolutePath string, value interface{}, l layer) (map[string]interface{}, error) {
+	mp, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, errors.New(fmt.Sprintf(
+			"Invalid map<object> value for %v \"%v\".", key, value))
+	}
+	var mapKeys []string
+	for mapKey := range mp {
+		mapKeys = append(mapKeys, mapKey)
+	}
+	sort.Strings(mapKeys)
+
+	var errs ValidationErrors
+	values := make(map[string]interface{}, len(mp))
+	for _, mapKey := range mapKeys {
+		elemConf, err := conf.parseObject(absolutePath+"."+mapKey, absolutePath, mp[mapKey], l)
+		errs.add("", err)
+		values[mapKey] = elemConf
+	}
+	return values, errs.err()
+}
+
+// isElemOption reports whether key is a child option of an array or a map
+// of objects. Such options are templates of each element and are never set
+// on conf itself.
+func (conf Config) isElemOption(key string) bool {
+	keyElems := strings.Split(key, ".")
+	for keyCount := 1; keyCount < len(keyElems); keyCount++ {
+		parentOpt := conf.findOptByKey(strings.Join(keyElems[:keyCount], "."))
+		if parentOpt != nil &&
+			(parentOpt.ValueType == "array<object>" || parentOpt.ValueType == "map<object>") {
+			return true
+		}
+	}
+	return false
+}
+
+// getIndexed resolves a key with array indexes such as "servers[1].host" or
+// "ports[0]".
+func (conf Config) getIndexed(key string) (interface{}, error) {
+	openIndex := strings.Index(key, "[")
+	value, err := conf.Get(key[:openIndex])
+	if err != nil {
+		return nil, err
+	}
+	path := key[:openIndex]
+	rest := key[openIndex:]
+	for rest != "" {
+		if rest[0] == '.' {
+			elemConf, ok := value.(Config)
 			if !ok {
-				return errors.New(fmt.Sprintf(
-					"Invalid string value for %v \"%v\".", key, kvs[key]))
+				return nil, &TypeMismatchError{
+					Key:  path,
+					Want: "object",
+					Got:  fmt.Sprintf("%T", value),
+				}
 			}
-			if err := opt.SetValue(str); err != nil {
-				return err
+			return elemConf.Get(rest[1:])
+		}
+		closeIndex := strings.Index(rest, "]")
+		if rest[0] != '[' || closeIndex < 0 {
+			return nil, errors.New(fmt.Sprintf("Invalid key \"%v\".", key))
+		}
+		index, err := strconv.Atoi(rest[1:closeIndex])
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf(
+				"Invalid index \"%v\" in key \"%v\".", rest[1:closeIndex], key))
+		}
+		ary, ok := value.([]interface{})
+		if !ok {
+			return nil, &TypeMismatchError{
+				Key:  path,
+				Want: "array",
+				Got:  fmt.Sprintf("%T", value),
 			}
 		}
+		if index < 0 || index >= len(ary) {
+			return nil, fmt.Errorf(
+				"%w: Index %v of key \"%v\" is out of range. \"%v\" has %v elements.",
+				ErrKeyNotFound, index, key, path, len(ary))
+		}
+		value = ary[index]
+		path += rest[:closeIndex+1]
+		rest = rest[closeIndex+1:]
 	}
-	return nil
+	return value, nil
+}
+
+// getMapValue gets the value of key in a map option, e.g. "limits.tenant"
+// of a map option "limits".
+func (conf Config) getMapValue(key string) (interface{}, bool) {
+	sep := strings.LastIndex(key, ".")
+	if sep < 0 {
+		return nil, false
+	}
+	mapOpt := conf.findOptByKey(key[:sep])
+	if mapOpt == nil {
+		return nil, false
+	}
+	if _, ok := configOption.MapValueType(mapOpt.ValueType); !ok && mapOpt.ValueType != "map" {
+		return nil, false
+	}
+	value, err := mapOpt.GetValue()
+	if err != nil {
+		return nil, false
+	}
+	mp, _ := value.(map[string]interface{})
+	mapValue, ok := mp[key[sep+1:]]
+	return mapValue, ok
+}
+
+func formatAllowedValues(opt configOption.Option) string {
+	valueType := opt.ValueType
+	if elemType, ok := configOption.ElementType(opt.ValueType); ok {
+		valueType = elemType
+	}
+	if mapValueType, ok := configOption.MapValueType(opt.ValueType); ok {
+		valueType = mapValueType
+	}
+	var strs []string
+	for _, allowedValue := range opt.AllowedValues {
+		strs = append(strs, formatValue(valueType, allowedValue))
+	}
+	return strings.Join(strs, ", ")
 }
 
 func sortOptsByKey(opts []configOption.Option) []configOption.Option {
@@ -180,10 +870,14 @@ func (conf *Config) AddOption(opt configOption.Option) error {
 	keyElems := strings.Split(validatedOpt.Key, ".")
 	if len(keyElems) > 1 {
 		for keyCount := 0; keyCount < len(keyElems)-1; keyCount++ {
+			// Parents of wildcard elements don't have to be declared.
+			if keyElems[keyCount] == "*" {
+				continue
+			}
 			joinedKey := strings.Join(keyElems[:keyCount+1], ".")
 			searchedOpt := conf.findOptByKey(joinedKey)
 			if searchedOpt == nil {
-				return errors.New(fmt.Sprintf("Parent option \"%v\" is not found.", searchedOpt))
+				return errors.New(fmt.Sprintf("Parent option \"%v\" is not found.", joinedKey))
 			}
 		}
 	}
@@ -202,11 +896,17 @@ func (conf *Config) AddOptions(opts []configOption.Option) error {
 }
 
 func (conf Config) Get(key string) (interface{}, error) {
+	if strings.Contains(key, "[") {
+		return conf.getIndexed(key)
+	}
 	// Find key from keys
 	opt := conf.findOptByKey(key)
 	// If requested key is not found, return error.
 	if opt == nil {
-		return nil, errors.New(fmt.Sprintf("Required key \"%v\" is not found.", key))
+		if mapValue, ok := conf.getMapValue(key); ok {
+			return mapValue, nil
+		}
+		return nil, fmt.Errorf("%w: %v", ErrKeyNotFound, key)
 	}
 
 	if opt.ValueType == "object" {
@@ -228,6 +928,103 @@ func (conf Config) GetAllKeys() []string {
 	return keys
 }
 
+func (conf Config) GetBool(key string) (bool, error) {
+	var zeroVal bool
+	value, err := conf.Get(key)
+	if err != nil {
+		return zeroVal, err
+	}
+	boolean, ok := value.(bool)
+	if !ok {
+		return zeroVal, &TypeMismatchError{
+			Key:  key,
+			Want: "bool",
+			Got:  fmt.Sprintf("%T", value),
+		}
+	}
+	return boolean, nil
+}
+
+func (conf Config) GetByteSize(key string) (int64, error) {
+	var zeroVal int64
+	value, err := conf.Get(key)
+	if err != nil {
+		return zeroVal, err
+	}
+	bytes, ok := value.(int64)
+	if !ok {
+		return zeroVal, &TypeMismatchError{
+			Key:  key,
+			Want: "bytesize (int64)",
+			Got:  fmt.Sprintf("%T", value),
+		}
+	}
+	return bytes, nil
+}
+
+func (conf Config) GetCIDR(key string) (netip.Prefix, error) {
+	var zeroVal netip.Prefix
+	value, err := conf.Get(key)
+	if err != nil {
+		return zeroVal, err
+	}
+	prefix, ok := value.(netip.Prefix)
+	if !ok {
+		return zeroVal, &TypeMismatchError{
+			Key:  key,
+			Want: "netip.Prefix",
+			Got:  fmt.Sprintf("%T", value),
+		}
+	}
+	return prefix, nil
+}
+
+func (conf Config) GetDuration(key string) (time.Duration, error) {
+	var zeroVal time.Duration
+	value, err := conf.Get(key)
+	if err != nil {
+		return zeroVal, err
+	}
+	duration, ok := value.(time.Duration)
+	if !ok {
+		return zeroVal, &TypeMismatchError{
+			Key:  key,
+			Want: "time.Duration",
+			Got:  fmt.Sprintf("%T", value),
+		}
+	}
+	return duration, nil
+}
+
+func (conf Config) GetDurationArray(key string) ([]time.Duration, error) {
+	var zeroVal []time.Duration
+	value, err := conf.Get(key)
+	if err != nil {
+		return zeroVal, err
+	}
+	ifArray, ok := value.([]interface{})
+	if !ok {
+		return zeroVal, &TypeMismatchError{
+			Key:  key,
+			Want: "[]interface{}",
+			Got:  fmt.Sprintf("%T", value),
+		}
+	}
+	var durationArray []time.Duration
+	for index, i := range ifArray {
+		duration, ok := i.(time.Duration)
+		if !ok {
+			return zeroVal, &TypeMismatchError{
+				Key:  fmt.Sprintf("%v[%v]", key, index),
+				Want: "time.Duration",
+				Got:  fmt.Sprintf("%T", i),
+			}
+		}
+		durationArray = append(durationArray, duration)
+	}
+	return durationArray, nil
+}
+
 func (conf Config) GetFloat64(key string) (float64, error) {
 	var zeroVal float64
 	value, err := conf.Get(key)
@@ -235,9 +1032,15 @@ func (conf Config) GetFloat64(key string) (float64, error) {
 		return zeroVal, err
 	}
 	flt64, ok := value.(float64)
+	if integer64, isInteger := integerOf(value); isInteger {
+		flt64, ok = float64(integer64), true
+	}
 	if !ok {
-		return zeroVal, errors.New(fmt.Sprintf(
-			"Value of option \"%v\" is not float64. Its type is %T.", key, value))
+		return zeroVal, &TypeMismatchError{
+			Key:  key,
+			Want: "float64",
+			Got:  fmt.Sprintf("%T", value),
+		}
 	}
 	return flt64, nil
 }
@@ -250,24 +1053,61 @@ func (conf Config) GetFloat64Array(key string) ([]float64, error) {
 	}
 	ifArray, ok := value.([]interface{})
 	if !ok {
-		return zeroVal, errors.New(fmt.Sprintf(
-			"Value of option \"%v\" is not []interface{}. Its type is %T.",
-			key,
-			value,
-		))
+		return zeroVal, &TypeMismatchError{
+			Key:  key,
+			Want: "[]interface{}",
+			Got:  fmt.Sprintf("%T", value),
+		}
 	}
 	var flt64Array []float64
-	for _, i := range ifArray {
-		flt64, ok := i.(float64)
+	for index, i := range ifArray {
+		flt64, ok := toFloat64(i)
 		if !ok {
-			return zeroVal, errors.New(fmt.Sprintf(
-				"Element of option \"%v\" is not float64. Its type is %T.", key, i))
+			return zeroVal, &TypeMismatchError{
+				Key:  fmt.Sprintf("%v[%v]", key, index),
+				Want: "float64",
+				Got:  fmt.Sprintf("%T", i),
+			}
 		}
 		flt64Array = append(flt64Array, flt64)
 	}
 	return flt64Array, nil
 }
 
+func (conf Config) GetHostPort(key string) (netip.AddrPort, error) {
+	var zeroVal netip.AddrPort
+	value, err := conf.Get(key)
+	if err != nil {
+		return zeroVal, err
+	}
+	addrPort, ok := value.(netip.AddrPort)
+	if !ok {
+		return zeroVal, &TypeMismatchError{
+			Key:  key,
+			Want: "netip.AddrPort",
+			Got:  fmt.Sprintf("%T", value),
+		}
+	}
+	return addrPort, nil
+}
+
+func (conf Config) GetIP(key string) (netip.Addr, error) {
+	var zeroVal netip.Addr
+	value, err := conf.Get(key)
+	if err != nil {
+		return zeroVal, err
+	}
+	addr, ok := value.(netip.Addr)
+	if !ok {
+		return zeroVal, &TypeMismatchError{
+			Key:  key,
+			Want: "netip.Addr",
+			Got:  fmt.Sprintf("%T", value),
+		}
+	}
+	return addr, nil
+}
+
 func (conf Config) GetInt(key string) (int, error) {
 	var zeroVal int
 	value, err := conf.Get(key)
@@ -275,23 +1115,44 @@ func (conf Config) GetInt(key string) (int, error) {
 		return zeroVal, err
 	}
 	integer, ok := value.(int)
+	if integer64, isInteger := integerOf(value); isInteger && !ok {
+		integer = int(integer64)
+		if int64(integer) != integer64 {
+			return zeroVal, &TypeMismatchError{
+				Key:  key,
+				Want: "int",
+				Got:  "int64",
+			}
+		}
+		ok = true
+	}
 	if !ok {
-		return zeroVal, errors.New(fmt.Sprintf(
-			"Value of option \"%v\" is not int. Its type is %T.", key, value))
+		return zeroVal, &TypeMismatchError{
+			Key:  key,
+			Want: "int",
+			Got:  fmt.Sprintf("%T", value),
+		}
 	}
 	return integer, nil
 }
 
 func (conf Config) GetIntArray(key string) ([]int, error) {
 	var zeroVal []int
-	flt64Array, err := conf.GetFloat64Array(key)
+	intArray64, err := conf.GetInt64Array(key)
 	if err != nil {
 		return zeroVal, err
 	}
 
 	var intArray []int
-	for _, flt64 := range flt64Array {
-		integer := int(flt64)
+	for index, integer64 := range intArray64 {
+		integer := int(integer64)
+		if int64(integer) != integer64 {
+			return zeroVal, &TypeMismatchError{
+				Key:  fmt.Sprintf("%v[%v]", key, index),
+				Want: "int",
+				Got:  "int64",
+			}
+		}
 		intArray = append(intArray, integer)
 	}
 	return intArray, nil
@@ -303,37 +1164,152 @@ func (conf Config) GetInt64(key string) (int64, error) {
 	if err != nil {
 		return zeroVal, err
 	}
-	integer64, ok := value.(int64)
+	integer64, ok := integerOf(value)
 	if !ok {
-		return zeroVal, errors.New(fmt.Sprintf(
-			"Value of option \"%v\" is not int64. Its type is %T.", key, value))
+		return zeroVal, &TypeMismatchError{
+			Key:  key,
+			Want: "int64",
+			Got:  fmt.Sprintf("%T", value),
+		}
 	}
 	return integer64, nil
 }
 
 func (conf Config) GetInt64Array(key string) ([]int64, error) {
 	var zeroVal []int64
-	flt64Array, err := conf.GetFloat64Array(key)
+	value, err := conf.Get(key)
 	if err != nil {
 		return zeroVal, err
 	}
-
+	ifArray, ok := value.([]interface{})
+	if !ok {
+		return zeroVal, &TypeMismatchError{
+			Key:  key,
+			Want: "[]interface{}",
+			Got:  fmt.Sprintf("%T", value),
+		}
+	}
 	var intArray64 []int64
-	for _, flt64 := range flt64Array {
-		integer64 := int64(flt64)
+	for index, i := range ifArray {
+		integer64, err := toInt64(i)
+		if err != nil {
+			return zeroVal, &TypeMismatchError{
+				Key:  fmt.Sprintf("%v[%v]", key, index),
+				Want: "int64",
+				Got:  fmt.Sprintf("%T", i),
+			}
+		}
 		intArray64 = append(intArray64, integer64)
 	}
 	return intArray64, nil
 }
 
+func (conf Config) GetObjectMap(key string) (map[string]Config, error) {
+	var zeroVal map[string]Config
+	mp, err := conf.GetMap(key)
+	if err != nil {
+		return zeroVal, err
+	}
+	confMap := make(map[string]Config, len(mp))
+	for mapKey, i := range mp {
+		elemConf, ok := i.(Config)
+		if !ok {
+			return zeroVal, &TypeMismatchError{
+				Key:  key + "." + mapKey,
+				Want: "Config",
+				Got:  fmt.Sprintf("%T", i),
+			}
+		}
+		confMap[mapKey] = elemConf
+	}
+	return confMap, nil
+}
+
+func (conf Config) GetObjectArray(key string) ([]Config, error) {
+	var zeroVal []Config
+	value, err := conf.Get(key)
+	if err != nil {
+		return zeroVal, err
+	}
+	ifArray, ok := value.([]interface{})
+	if !ok {
+		return zeroVal, &TypeMismatchError{
+			Key:  key,
+			Want: "[]interface{}",
+			Got:  fmt.Sprintf("%T", value),
+		}
+	}
+	var confArray []Config
+	for index, i := range ifArray {
+		elemConf, ok := i.(Config)
+		if !ok {
+			return zeroVal, &TypeMismatchError{
+				Key:  fmt.Sprintf("%v[%v]", key, index),
+				Want: "Config",
+				Got:  fmt.Sprintf("%T", i),
+			}
+		}
+		confArray = append(confArray, elemConf)
+	}
+	return confArray, nil
+}
+
+func (conf Config) GetMap(key string) (map[string]interface{}, error) {
+	var zeroVal map[string]interface{}
+	value, err := conf.Get(key)
+	if err != nil {
+		return zeroVal, err
+	}
+	mp, ok := value.(map[string]interface{})
+	if !ok {
+		return zeroVal, &TypeMismatchError{
+			Key:  key,
+			Want: "map[string]interface{}",
+			Got:  fmt.Sprintf("%T", value),
+		}
+	}
+	return mp, nil
+}
+
+// GetMapKeys returns the sorted keys of a map option.
+func (conf Config) GetMapKeys(key string) ([]string, error) {
+	mp, err := conf.GetMap(key)
+	if err != nil {
+		return nil, err
+	}
+	var keys []string
+	for mapKey := range mp {
+		keys = append(keys, mapKey)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
 func (conf Config) GetObject(key string) (Config, error) {
 	var childConf Config
 
+	// Elements of arrays of objects are Configs.
+	if strings.Contains(key, "[") {
+		value, err := conf.getIndexed(key)
+		if err != nil {
+			return childConf, err
+		}
+		elemConf, ok := value.(Config)
+		if !ok {
+			return childConf, &TypeMismatchError{
+				Key:  key,
+				Want: "object",
+				Got:  fmt.Sprintf("%T", value),
+			}
+		}
+		return elemConf, nil
+	}
+
 	// Find key from keys
 	opt := conf.findOptByKey(key)
 	// If requested key is not found, return error.
 	if opt == nil {
-		return childConf, errors.New(fmt.Sprintf("Required key \"%v\" is not found.", key))
+		return childConf, fmt.Errorf("%w: %v", ErrKeyNotFound, key)
 	}
 
 	for _, opt := range conf.options {
@@ -353,12 +1329,36 @@ func (conf Config) GetString(key string) (string, error) {
 	}
 	str, ok := value.(string)
 	if !ok {
-		return zeroVal, errors.New(fmt.Sprintf(
-			"Value of option \"%v\" is not string. Its type is %T.", key, value))
+		return zeroVal, &TypeMismatchError{
+			Key:  key,
+			Want: "string",
+			Got:  fmt.Sprintf("%T", value),
+		}
 	}
 	return str, nil
 }
 
+func (conf Config) GetStringMap(key string) (map[string]string, error) {
+	var zeroVal map[string]string
+	mp, err := conf.GetMap(key)
+	if err != nil {
+		return zeroVal, err
+	}
+	stringMap := make(map[string]string, len(mp))
+	for mapKey, i := range mp {
+		str, ok := i.(string)
+		if !ok {
+			return zeroVal, &TypeMismatchError{
+				Key:  key + "." + mapKey,
+				Want: "string",
+				Got:  fmt.Sprintf("%T", i),
+			}
+		}
+		stringMap[mapKey] = str
+	}
+	return stringMap, nil
+}
+
 func (conf Config) GetStringArray(key string) ([]string, error) {
 	var zeroVal []string
 	value, err := conf.Get(key)
@@ -367,38 +1367,127 @@ func (conf Config) GetStringArray(key string) ([]string, error) {
 	}
 	ifArray, ok := value.([]interface{})
 	if !ok {
-		return zeroVal, errors.New(fmt.Sprintf(
-			"Value of option \"%v\" is not []interface{}. Its type is %T.",
-			key,
-			value,
-		))
+		return zeroVal, &TypeMismatchError{
+			Key:  key,
+			Want: "[]interface{}",
+			Got:  fmt.Sprintf("%T", value),
+		}
 	}
 	var stringArray []string
-	for _, i := range ifArray {
+	for index, i := range ifArray {
 		str, ok := i.(string)
 		if !ok {
-			return zeroVal, errors.New(fmt.Sprintf(
-				"Element of option \"%v\" is not float64. Its type is %T.", key, i))
+			return zeroVal, &TypeMismatchError{
+				Key:  fmt.Sprintf("%v[%v]", key, index),
+				Want: "string",
+				Got:  fmt.Sprintf("%T", i),
+			}
 		}
 		stringArray = append(stringArray, str)
 	}
 	return stringArray, nil
 }
 
+func (conf Config) GetTime(key string) (time.Time, error) {
+	var zeroVal time.Time
+	value, err := conf.Get(key)
+	if err != nil {
+		return zeroVal, err
+	}
+	tm, ok := value.(time.Time)
+	if !ok {
+		return zeroVal, &TypeMismatchError{
+			Key:  key,
+			Want: "time.Time",
+			Got:  fmt.Sprintf("%T", value),
+		}
+	}
+	return tm, nil
+}
+
+func (conf Config) GetURL(key string) (*url.URL, error) {
+	var zeroVal *url.URL
+	value, err := conf.Get(key)
+	if err != nil {
+		return zeroVal, err
+	}
+	urlPtr, ok := value.(*url.URL)
+	if !ok {
+		return zeroVal, &TypeMismatchError{
+			Key:  key,
+			Want: "*url.URL",
+			Got:  fmt.Sprintf("%T", value),
+		}
+	}
+	return urlPtr, nil
+}
+
+// Parse reads the file at path with the decoder registered for its
+// extension. Files with an unknown extension are parsed as JSON.
 func (conf *Config) Parse(path string) error {
+	return conf.ParseFormat(path, formatOf(path))
+}
+
+// ParseBytes decodes raw with the decoder registered as format.
+func (conf *Config) ParseBytes(raw []byte, format string) error {
+	return conf.parseBytes("", raw, format)
+}
+
+// ParseFormat reads the file at path with the decoder registered as format.
+func (conf *Config) ParseFormat(path string, format string) error {
 	/// Read config file and parse into "map[string]interface{}"
 	raw, readFileErr := ioutil.ReadFile(path)
 	if readFileErr != nil {
 		return readFileErr
 	}
-	keyValues := make(map[string]interface{})
-	if unmarshalErr := json.Unmarshal(raw, &(keyValues)); unmarshalErr != nil {
-		return unmarshalErr
+	return conf.parseBytes(path, raw, format)
+}
+
+// ParseFS reads the file at path in fsys (e.g. an embed.FS) with the
+// decoder registered for its extension.
+func (conf *Config) ParseFS(fsys fs.FS, path string) error {
+	raw, readFileErr := fs.ReadFile(fsys, path)
+	if readFileErr != nil {
+		return readFileErr
 	}
-	if err := conf.parseOneLayer(keyValues, ""); err != nil {
-		return err
+	return conf.parseBytes(path, raw, formatOf(path))
+}
+
+// ParseReader reads all of reader (e.g. os.Stdin) with the decoder
+// registered as format.
+func (conf *Config) ParseReader(reader io.Reader, format string) error {
+	raw, readErr := ioutil.ReadAll(reader)
+	if readErr != nil {
+		return readErr
 	}
-	return conf.Validate()
+	return conf.ParseBytes(raw, format)
+}
+
+// ParseKeyValues sets options from decoded key values in the shape
+// encoding/json produces with UseNumber, overlays environment variables and
+// flags bound by BindEnv and BindFlags, then validates them. Integers may
+// also be int64 and datetimes time.Time for formats that have those types
+// natively. All failures are reported together as ValidationErrors.
+func (conf *Config) ParseKeyValues(keyValues map[string]interface{}) error {
+	return conf.parseKeyValues(keyValues, layer{
+		origin: configOption.Origin{Kind: configOption.ORIGIN_KEY_VALUES},
+	})
+}
+
+// parseKeyValues is ParseKeyValues of keyValues read as l.
+func (conf *Config) parseKeyValues(keyValues map[string]interface{}, l layer) error {
+	var errs ValidationErrors
+	errs.add("", conf.checkUnknownKeys(keyValues))
+	errs.add("", conf.parseOneLayer(keyValues, "", l))
+	locateErrors(errs, l.locations)
+	// Environment variables take precedence over the parsed values,
+	// and flags take precedence over environment variables.
+	if conf.envBound {
+		errs.add("", conf.parseEnv(conf.envPrefix))
+	}
+	errs.add("", conf.parseFlags())
+	errs.add("", conf.Validate())
+	return errs.err()
 }
 
 func (conf Config) String() string {
@@ -424,6 +1513,10 @@ func (conf Config) String() string {
 		if opt.Required == true {
 			str += " (required)"
 		}
+		// allowed values
+		if len(opt.AllowedValues) > 0 {
+			str += " (one of: " + formatAllowedValues(opt) + ")"
+		}
 		// default value
 		if opt.ValueType != "" && opt.ValueType != "nil" {
 			if opt.DefaultValue != nil {
@@ -433,6 +1526,23 @@ func (conf Config) String() string {
 					if ok {
 						str += fmt.Sprintf(" (default: %v)", defaultValArray)
 					}
+				case "bool":
+					defaultValBool, ok := opt.DefaultValue.(bool)
+					if ok {
+						str += fmt.Sprintf(" (default: %v)", defaultValBool)
+					}
+				case "bytesize":
+					defaultValBytes, ok := opt.DefaultValue.(int64)
+					if ok {
+						str += fmt.Sprintf(" (default: %vB)", defaultValBytes)
+					}
+				case "cidr", "hostport", "ip", "url":
+					str += fmt.Sprintf(" (default: %v)", opt.DefaultValue)
+				case "duration":
+					defaultValDuration, ok := opt.DefaultValue.(time.Duration)
+					if ok {
+						str += fmt.Sprintf(" (default: %v)", defaultValDuration)
+					}
 				case "float64":
 					defaultValFlt64, ok := opt.DefaultValue.(float64)
 					if ok {
@@ -453,6 +1563,17 @@ func (conf Config) String() string {
 					if ok {
 						str += fmt.Sprintf(" (default: \"%v\")", defaultValStr)
 					}
+				case "time":
+					defaultValTime, ok := opt.DefaultValue.(time.Time)
+					if ok {
+						str += fmt.Sprintf(" (default: %v)", defaultValTime.Format(time.RFC3339))
+					}
+				default:
+					_, isArray := configOption.ElementType(opt.ValueType)
+					_, isMap := configOption.MapValueType(opt.ValueType)
+					if isArray || isMap || opt.ValueType == "map" {
+						str += fmt.Sprintf(" (default: %v)", opt.DefaultValue)
+					}
 				}
 			}
 		}
@@ -461,11 +1582,16 @@ func (conf Config) String() string {
 	return str
 }
 
+// Validate checks required options and validators of all options, and
+// reports all failures as ValidationErrors.
 func (conf Config) Validate() error {
+	var errs ValidationErrors
 	for _, opt := range conf.options {
-		if err := opt.Validate(); err != nil {
-			return err
+		if conf.isElemOption(opt.Key) || isPattern(opt.Key) {
+			continue
 		}
+		errs.add(opt.Key, opt.Validate())
 	}
-	return nil
+	locateErrors(errs, conf.locations)
+	return errs.err()
 }
@@ -5,7 +5,11 @@ package config
  */
 
 import (
+	"net/netip"
+	"strings"
 	"testing"
+	"testing/fstest"
+	"time"
 
 	"github.com/mozzzzy/config/json/configOption"
 	"github.com/mozzzzy/config/validator"
@@ -20,6 +24,8 @@ import (
  * Constants and Package Scope Variables
  */
 
+const BIG_INT64_JSON string = "testData/big_int64.json"
+const ONE_BOOL_JSON string = "testData/one_bool.json"
 const ONE_FLOAT64_JSON string = "testData/one_float64.json"
 const ONE_FLOAT64_ARRAY_JSON string = "testData/one_float64_array.json"
 const ONE_INT_JSON string = "testData/one_int.json"
@@ -30,6 +36,13 @@ const ONE_OBJECT_JSON string = "testData/one_object.json"
 const ONE_STRING_JSON string = "testData/one_string.json"
 const ONE_STRING_ARRAY_JSON string = "testData/one_string_array.json"
 const ALL_IN_ONE_JSON string = "testData/all_in_one.json"
+const NETWORK_JSON string = "testData/network.json"
+const UNITS_JSON string = "testData/units.json"
+const OVERFLOW_INT64_JSON string = "testData/overflow_int64.json"
+const TYPED_ARRAY_JSON string = "testData/typed_array.json"
+const SERVERS_JSON string = "testData/servers.json"
+const MAPS_JSON string = "testData/maps.json"
+const PLUGINS_JSON string = "testData/plugins.json"
 
 /*
  * Functions
@@ -161,6 +174,35 @@ func TestAddOption(t *testing.T) {
 		)
 		testUtil.WithError(t, err)
 	})
+
+	t.Run("wildcard", func(t *testing.T) {
+		var conf Config
+		err := conf.AddOptions([]configOption.Option{
+			{
+				Key:         "plugins",
+				ValueType:   "object",
+				Description: "some description.",
+			},
+			{
+				Key:         "plugins.*.enabled",
+				ValueType:   "bool",
+				Description: "some description.",
+			},
+		})
+		testUtil.NoError(t, err)
+	})
+
+	t.Run("invalid (parent of wildcard is not found)", func(t *testing.T) {
+		var conf Config
+		err := conf.AddOption(
+			configOption.Option{
+				Key:         "plugins.*.enabled",
+				ValueType:   "bool",
+				Description: "some description.",
+			},
+		)
+		testUtil.WithError(t, err)
+	})
 }
 
 func TestAddOptions(t *testing.T) {
@@ -233,6 +275,76 @@ func TestGet(t *testing.T) {
 		testUtil.Match(t, expect[1], secondElem)
 	})
 
+	t.Run("get array of numbers", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "array",
+				ValueType:   "array",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.ParseBytes([]byte(`{"array": [80, 1.5, [443]]}`), "json")
+		testUtil.NoError(t, parseErr)
+
+		actual, getErr := conf.Get("array")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, []interface{}{80.0, 1.5, []interface{}{443.0}}, actual)
+	})
+
+	t.Run("get array of large integers", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions([]configOption.Option{
+			{
+				Key:         "untyped",
+				ValueType:   "array",
+				Description: "some description.",
+			},
+			{
+				Key:         "typed",
+				ValueType:   "array<int64>",
+				Description: "some description.",
+			},
+		})
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.ParseBytes([]byte(
+			`{"untyped": [9007199254740993], "typed": [9007199254740993]}`), "json")
+		testUtil.NoError(t, parseErr)
+
+		// elements of untyped arrays are float64
+		untyped, getErr := conf.Get("untyped")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, []interface{}{9007199254740992.0}, untyped)
+		_, getErr = conf.GetInt64("untyped[0]")
+		testUtil.WithError(t, getErr)
+
+		typed, getErr := conf.GetInt64Array("typed")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, []int64{9007199254740993}, typed)
+	})
+
+	t.Run("get map of numbers", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "map",
+				ValueType:   "map",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.ParseBytes([]byte(`{"map": {"int": 80, "float64": 1.5}}`), "json")
+		testUtil.NoError(t, parseErr)
+
+		actual, getErr := conf.Get("map")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, map[string]interface{}{"int": 80.0, "float64": 1.5}, actual)
+	})
+
 	t.Run("get float64", func(t *testing.T) {
 		var conf Config
 		addOptionErr := conf.AddOption(
@@ -499,6 +611,163 @@ func TestGet(t *testing.T) {
 		testUtil.Match(t, true, ok)
 		testUtil.Match(t, expectStr2, castedActualStr2)
 	})
+
+	t.Run("get with array index", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions([]configOption.Option{
+			{
+				Key:         "ports",
+				ValueType:   "array<int>",
+				Description: "some description.",
+			},
+			{
+				Key:         "tags",
+				ValueType:   "array",
+				Description: "some description.",
+			},
+		})
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(TYPED_ARRAY_JSON)
+		testUtil.NoError(t, parseErr)
+
+		port, getErr := conf.GetInt("ports[0]")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, 80, port)
+
+		tag, getErr := conf.GetString("tags[1]")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, "value", tag)
+	})
+
+	t.Run("get with array index of untyped array", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions([]configOption.Option{
+			{
+				Key:         "ports",
+				ValueType:   "array",
+				Description: "some description.",
+			},
+			{
+				Key:         "weights",
+				ValueType:   "array",
+				Description: "some description.",
+			},
+		})
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.ParseBytes([]byte(`{"ports": [80, 443], "weights": [0.5, 2]}`), "json")
+		testUtil.NoError(t, parseErr)
+
+		port, getErr := conf.GetInt("ports[0]")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, 80, port)
+
+		port64, getErr := conf.GetInt64("ports[1]")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, int64(443), port64)
+
+		weight, getErr := conf.GetFloat64("weights[1]")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, 2.0, weight)
+
+		_, getErr = conf.GetInt("weights[0]")
+		testUtil.WithError(t, getErr)
+	})
+
+	t.Run("get with array index of untyped array with int elements", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "ports",
+				ValueType:   "array",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		// e.g. key values decoded from yaml
+		parseErr := conf.ParseKeyValues(map[string]interface{}{
+			"ports": []interface{}{80, 443},
+		})
+		testUtil.NoError(t, parseErr)
+
+		port, getErr := conf.GetInt("ports[1]")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, 443, port)
+	})
+
+	t.Run("get with array index of objects", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions([]configOption.Option{
+			{
+				Key:         "servers",
+				ValueType:   "array<object>",
+				Description: "some description.",
+			},
+			{
+				Key:         "servers.host",
+				ValueType:   "string",
+				Description: "some description.",
+			},
+		})
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(SERVERS_JSON)
+		testUtil.NoError(t, parseErr)
+
+		host, getErr := conf.GetString("servers[1].host")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, "beta.example.com", host)
+
+		server, getErr := conf.GetObject("servers[0]")
+		testUtil.NoError(t, getErr)
+		host, getErr = server.GetString("host")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, "alpha.example.com", host)
+	})
+
+	t.Run("invalid (index is out of range)", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "ports",
+				ValueType:   "array<int>",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(TYPED_ARRAY_JSON)
+		testUtil.NoError(t, parseErr)
+
+		_, getErr := conf.Get("ports[2]")
+		testUtil.WithError(t, getErr)
+
+		_, getErr = conf.Get("ports[-1]")
+		testUtil.WithError(t, getErr)
+
+		_, getErr = conf.Get("ports[a]")
+		testUtil.WithError(t, getErr)
+	})
+
+	t.Run("invalid (index of not an array)", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "int",
+				ValueType:   "int",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(ONE_INT_JSON)
+		testUtil.NoError(t, parseErr)
+
+		_, getErr := conf.Get("int[0]")
+		testUtil.WithError(t, getErr)
+	})
 }
 
 func TestGetAllKeys(t *testing.T) {
@@ -606,6 +875,27 @@ func TestGetInt64Array(t *testing.T) {
 	})
 }
 
+func TestGetStringMap(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "headers",
+				ValueType:   "map<string>",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(MAPS_JSON)
+		testUtil.NoError(t, parseErr)
+
+		actual, getErr := conf.GetStringMap("headers")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, map[string]string{"X-Env": "prod", "X-Team": "core"}, actual)
+	})
+}
+
 func TestGetStringArray(t *testing.T) {
 	t.Run("valid", func(t *testing.T) {
 		var conf Config
@@ -633,251 +923,282 @@ func TestGetStringArray(t *testing.T) {
 	})
 }
 
-func TestGetFloat64(t *testing.T) {
+func TestGetBool(t *testing.T) {
 	t.Run("valid", func(t *testing.T) {
 		var conf Config
 		addOptionErr := conf.AddOption(
 			configOption.Option{
-				Key:         "float64",
-				ValueType:   "float64",
+				Key:         "bool",
+				ValueType:   "bool",
 				Description: "some description.",
 			},
 		)
 		testUtil.NoError(t, addOptionErr)
 
-		parseErr := conf.Parse(ONE_FLOAT64_JSON)
+		parseErr := conf.Parse(ONE_BOOL_JSON)
 		testUtil.NoError(t, parseErr)
 
-		var expect float64 = 1.2
-		actual, getErr := conf.GetFloat64("float64")
+		actual, getErr := conf.GetBool("bool")
 		testUtil.NoError(t, getErr)
-		testUtil.Match(t, expect, actual)
+		testUtil.Match(t, true, actual)
 	})
 
-	t.Run("valid in object", func(t *testing.T) {
+	t.Run("valid default value", func(t *testing.T) {
 		var conf Config
-		addOptionErr := conf.AddOptions([]configOption.Option{
-			{
-				Key:         "object",
-				ValueType:   "object",
-				Description: "some description.",
-			},
-			{
-				Key:         "object.float64",
-				ValueType:   "float64",
-				Description: "some description.",
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:          "bool",
+				ValueType:    "bool",
+				Description:  "some description.",
+				DefaultValue: false,
 			},
-		})
+		)
 		testUtil.NoError(t, addOptionErr)
 
-		parseErr := conf.Parse(ALL_IN_ONE_JSON)
+		parseErr := conf.Parse(ONE_STRING_JSON)
 		testUtil.NoError(t, parseErr)
 
-		var expect float64 = 2.2
-		actual, getErr := conf.GetFloat64("object.float64")
+		actual, getErr := conf.GetBool("bool")
 		testUtil.NoError(t, getErr)
-		testUtil.Match(t, expect, actual)
+		testUtil.Match(t, false, actual)
 	})
 
-	t.Run("invalid (string)", func(t *testing.T) {
+	t.Run("invalid (type is bool <-> value is string)", func(t *testing.T) {
 		var conf Config
 		addOptionErr := conf.AddOption(
 			configOption.Option{
 				Key:         "string",
-				ValueType:   "string",
+				ValueType:   "bool",
 				Description: "some description.",
 			},
 		)
 		testUtil.NoError(t, addOptionErr)
 
 		parseErr := conf.Parse(ONE_STRING_JSON)
-		testUtil.NoError(t, parseErr)
-
-		_, getErr := conf.GetFloat64("string")
-		testUtil.WithError(t, getErr)
+		testUtil.WithError(t, parseErr)
 	})
 }
 
-func TestGetInt(t *testing.T) {
+func TestGetByteSize(t *testing.T) {
 	t.Run("valid", func(t *testing.T) {
 		var conf Config
 		addOptionErr := conf.AddOption(
 			configOption.Option{
-				Key:         "int",
-				ValueType:   "int",
+				Key:         "bytesize",
+				ValueType:   "bytesize",
 				Description: "some description.",
 			},
 		)
 		testUtil.NoError(t, addOptionErr)
 
-		parseErr := conf.Parse(ONE_INT_JSON)
+		parseErr := conf.Parse(UNITS_JSON)
 		testUtil.NoError(t, parseErr)
 
-		var expect int = 10
-		actual, getErr := conf.GetInt("int")
+		actual, getErr := conf.GetByteSize("bytesize")
 		testUtil.NoError(t, getErr)
-		testUtil.Match(t, expect, actual)
+		testUtil.Match(t, int64(512*1024*1024), actual)
 	})
 
-	t.Run("valid in object", func(t *testing.T) {
+	t.Run("valid number", func(t *testing.T) {
 		var conf Config
-		addOptionErr := conf.AddOptions([]configOption.Option{
-			{
-				Key:         "object",
-				ValueType:   "object",
-				Description: "some description.",
-			},
-			{
-				Key:         "object.int",
-				ValueType:   "int",
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "int",
+				ValueType:   "bytesize",
 				Description: "some description.",
 			},
-		})
+		)
 		testUtil.NoError(t, addOptionErr)
 
-		parseErr := conf.Parse(ALL_IN_ONE_JSON)
+		parseErr := conf.Parse(ONE_INT_JSON)
 		testUtil.NoError(t, parseErr)
 
-		var expect int = 20
-		actual, getErr := conf.GetInt("object.int")
+		actual, getErr := conf.GetByteSize("int")
 		testUtil.NoError(t, getErr)
-		testUtil.Match(t, expect, actual)
+		testUtil.Match(t, int64(10), actual)
 	})
 
-	t.Run("invalid (string)", func(t *testing.T) {
+	t.Run("invalid (type is bytesize <-> value is string)", func(t *testing.T) {
 		var conf Config
 		addOptionErr := conf.AddOption(
 			configOption.Option{
 				Key:         "string",
-				ValueType:   "string",
+				ValueType:   "bytesize",
 				Description: "some description.",
 			},
 		)
 		testUtil.NoError(t, addOptionErr)
 
 		parseErr := conf.Parse(ONE_STRING_JSON)
-		testUtil.NoError(t, parseErr)
+		testUtil.WithError(t, parseErr)
+	})
 
-		_, getErr := conf.GetInt("string")
-		testUtil.WithError(t, getErr)
+	t.Run("invalid (negative)", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "bytesize",
+				ValueType:   "bytesize",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.ParseBytes([]byte(`{"bytesize": "-5MB"}`), "json")
+		testUtil.WithError(t, parseErr)
+
+		parseErr = conf.ParseBytes([]byte(`{"bytesize": -5}`), "json")
+		testUtil.WithError(t, parseErr)
 	})
 }
 
-func TestGetInt64(t *testing.T) {
+func TestGetCIDR(t *testing.T) {
 	t.Run("valid", func(t *testing.T) {
 		var conf Config
 		addOptionErr := conf.AddOption(
 			configOption.Option{
-				Key:         "int64",
-				ValueType:   "int64",
+				Key:         "cidr",
+				ValueType:   "cidr",
 				Description: "some description.",
 			},
 		)
 		testUtil.NoError(t, addOptionErr)
 
-		parseErr := conf.Parse(ONE_INT64_JSON)
+		parseErr := conf.Parse(NETWORK_JSON)
 		testUtil.NoError(t, parseErr)
 
-		var expect int64 = 2147483648
-		actual, getErr := conf.GetInt64("int64")
+		actual, getErr := conf.GetCIDR("cidr")
 		testUtil.NoError(t, getErr)
-		testUtil.Match(t, expect, actual)
+		testUtil.Match(t, netip.MustParsePrefix("10.0.0.0/8"), actual)
 	})
 
-	t.Run("valid in object", func(t *testing.T) {
+	t.Run("invalid (value is not cidr)", func(t *testing.T) {
 		var conf Config
-		addOptionErr := conf.AddOptions([]configOption.Option{
-			{
-				Key:         "object",
-				ValueType:   "object",
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "invalid",
+				ValueType:   "cidr",
 				Description: "some description.",
 			},
-			{
-				Key:         "object.int",
-				ValueType:   "int",
-				Description: "some description.",
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(NETWORK_JSON)
+		testUtil.WithError(t, parseErr)
+	})
+}
+
+func TestGetDuration(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:            "duration",
+				ValueType:      "duration",
+				Description:    "some description.",
+				Validator:      validator.DurationWithin,
+				ValidatorParam: []time.Duration{time.Second, time.Hour},
 			},
-		})
+		)
 		testUtil.NoError(t, addOptionErr)
 
-		parseErr := conf.Parse(ALL_IN_ONE_JSON)
+		parseErr := conf.Parse(UNITS_JSON)
 		testUtil.NoError(t, parseErr)
 
-		var expect int = 20
-		actual, getErr := conf.GetInt("object.int")
+		actual, getErr := conf.GetDuration("duration")
 		testUtil.NoError(t, getErr)
-		testUtil.Match(t, expect, actual)
+		testUtil.Match(t, 90*time.Second, actual)
 	})
 
-	t.Run("invalid (string)", func(t *testing.T) {
+	t.Run("valid default value", func(t *testing.T) {
 		var conf Config
 		addOptionErr := conf.AddOption(
 			configOption.Option{
-				Key:         "string",
-				ValueType:   "string",
-				Description: "some description.",
+				Key:          "timeout",
+				ValueType:    "duration",
+				Description:  "some description.",
+				DefaultValue: 5 * time.Second,
 			},
 		)
 		testUtil.NoError(t, addOptionErr)
 
-		parseErr := conf.Parse(ONE_STRING_JSON)
+		parseErr := conf.Parse(UNITS_JSON)
 		testUtil.NoError(t, parseErr)
 
-		_, getErr := conf.GetInt("string")
-		testUtil.WithError(t, getErr)
+		actual, getErr := conf.GetDuration("timeout")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, 5*time.Second, actual)
 	})
-}
 
+	t.Run("invalid (type is duration <-> value is int)", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "int",
+				ValueType:   "duration",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
 
+		parseErr := conf.Parse(ONE_INT_JSON)
+		testUtil.WithError(t, parseErr)
+	})
+}
 
-func TestGetObject(t *testing.T) {
+func TestGetDurationArray(t *testing.T) {
 	t.Run("valid", func(t *testing.T) {
 		var conf Config
-		addOptionErr := conf.AddOptions([]configOption.Option{
-			{
-				Key:         "object",
-				ValueType:   "object",
-				Description: "some description.",
-			},
-			{
-				Key:         "object.key",
-				ValueType:   "string",
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "durations",
+				ValueType:   "array<duration>",
 				Description: "some description.",
 			},
-		})
+		)
 		testUtil.NoError(t, addOptionErr)
 
-		parseErr := conf.Parse(ONE_OBJECT_JSON)
+		parseErr := conf.Parse(TYPED_ARRAY_JSON)
 		testUtil.NoError(t, parseErr)
 
-		childConf, getErr := conf.GetObject("object")
+		actual, getErr := conf.GetDurationArray("durations")
 		testUtil.NoError(t, getErr)
+		testUtil.Match(t, []time.Duration{time.Second, 90 * time.Second}, actual)
+	})
 
-		actual, getStringErr := childConf.GetString("key")
-		testUtil.NoError(t, getStringErr)
+	t.Run("invalid (element type is duration <-> value is int)", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "ports",
+				ValueType:   "array<duration>",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
 
-		expect := "value"
-		testUtil.Match(t, expect, actual)
+		parseErr := conf.Parse(TYPED_ARRAY_JSON)
+		testUtil.WithError(t, parseErr)
 	})
 }
 
-func TestGetString(t *testing.T) {
+func TestGetFloat64(t *testing.T) {
 	t.Run("valid", func(t *testing.T) {
 		var conf Config
 		addOptionErr := conf.AddOption(
 			configOption.Option{
-				Key:         "string",
-				ValueType:   "string",
+				Key:         "float64",
+				ValueType:   "float64",
 				Description: "some description.",
 			},
 		)
 		testUtil.NoError(t, addOptionErr)
 
-		parseErr := conf.Parse(ONE_STRING_JSON)
+		parseErr := conf.Parse(ONE_FLOAT64_JSON)
 		testUtil.NoError(t, parseErr)
 
-		var expect string = "some value"
-		actual, getErr := conf.GetString("string")
+		var expect float64 = 1.2
+		actual, getErr := conf.GetFloat64("float64")
 		testUtil.NoError(t, getErr)
 		testUtil.Match(t, expect, actual)
 	})
@@ -891,8 +1212,8 @@ func TestGetString(t *testing.T) {
 				Description: "some description.",
 			},
 			{
-				Key:         "object.string",
-				ValueType:   "string",
+				Key:         "object.float64",
+				ValueType:   "float64",
 				Description: "some description.",
 			},
 		})
@@ -901,68 +1222,130 @@ func TestGetString(t *testing.T) {
 		parseErr := conf.Parse(ALL_IN_ONE_JSON)
 		testUtil.NoError(t, parseErr)
 
-		var expect string = "some value in object"
-		actual, getErr := conf.GetString("object.string")
+		var expect float64 = 2.2
+		actual, getErr := conf.GetFloat64("object.float64")
 		testUtil.NoError(t, getErr)
 		testUtil.Match(t, expect, actual)
 	})
 
-	t.Run("invalid (int)", func(t *testing.T) {
+	t.Run("invalid (string)", func(t *testing.T) {
 		var conf Config
 		addOptionErr := conf.AddOption(
 			configOption.Option{
-				Key:         "int",
-				ValueType:   "int",
+				Key:         "string",
+				ValueType:   "string",
 				Description: "some description.",
 			},
 		)
 		testUtil.NoError(t, addOptionErr)
 
-		parseErr := conf.Parse(ONE_INT_JSON)
+		parseErr := conf.Parse(ONE_STRING_JSON)
 		testUtil.NoError(t, parseErr)
 
-		_, getErr := conf.GetString("int")
+		_, getErr := conf.GetFloat64("string")
 		testUtil.WithError(t, getErr)
 	})
 }
 
-func TestParse(t *testing.T) {
-	t.Run("valid multi", func(t *testing.T) {
+func TestGetHostPort(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
 		var conf Config
-		addOptionErr := conf.AddOptions([]configOption.Option{
-			{
-				Key:         "array",
-				ValueType:   "array",
-				Description: "some description.",
-			},
-			{
-				Key:         "float64",
-				ValueType:   "float64",
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "hostport",
+				ValueType:   "hostport",
 				Description: "some description.",
 			},
-			{
-				Key:         "int",
-				ValueType:   "int",
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(NETWORK_JSON)
+		testUtil.NoError(t, parseErr)
+
+		actual, getErr := conf.GetHostPort("hostport")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, netip.MustParseAddrPort("127.0.0.1:8080"), actual)
+	})
+
+	t.Run("invalid (value is not hostport)", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "invalid",
+				ValueType:   "hostport",
 				Description: "some description.",
 			},
-			{
-				Key:         "string",
-				ValueType:   "string",
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(NETWORK_JSON)
+		testUtil.WithError(t, parseErr)
+	})
+}
+
+func TestGetIP(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "ip",
+				ValueType:   "ip",
 				Description: "some description.",
 			},
-			{
-				Key:         "object",
-				ValueType:   "object",
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(NETWORK_JSON)
+		testUtil.NoError(t, parseErr)
+
+		actual, getErr := conf.GetIP("ip")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, netip.IPv6Loopback(), actual)
+	})
+
+	t.Run("invalid (value is not ip)", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "invalid",
+				ValueType:   "ip",
 				Description: "some description.",
 			},
-			{
-				Key:         "object.array",
-				ValueType:   "array",
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(NETWORK_JSON)
+		testUtil.WithError(t, parseErr)
+	})
+}
+
+func TestGetInt(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "int",
+				ValueType:   "int",
 				Description: "some description.",
 			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(ONE_INT_JSON)
+		testUtil.NoError(t, parseErr)
+
+		var expect int = 10
+		actual, getErr := conf.GetInt("int")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, expect, actual)
+	})
+
+	t.Run("valid in object", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions([]configOption.Option{
 			{
-				Key:         "object.float64",
-				ValueType:   "float64",
+				Key:         "object",
+				ValueType:   "object",
 				Description: "some description.",
 			},
 			{
@@ -970,31 +1353,952 @@ func TestParse(t *testing.T) {
 				ValueType:   "int",
 				Description: "some description.",
 			},
-			{
-				Key:         "object.string",
-				ValueType:   "string",
-				Description: "some description.",
-			},
 		})
 		testUtil.NoError(t, addOptionErr)
 
 		parseErr := conf.Parse(ALL_IN_ONE_JSON)
 		testUtil.NoError(t, parseErr)
+
+		var expect int = 20
+		actual, getErr := conf.GetInt("object.int")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, expect, actual)
 	})
 
-	t.Run("invalid (type is int <-> value is string)", func(t *testing.T) {
+	t.Run("invalid (string)", func(t *testing.T) {
 		var conf Config
 		addOptionErr := conf.AddOption(
 			configOption.Option{
 				Key:         "string",
-				ValueType:   "int", // this should be string
+				ValueType:   "string",
 				Description: "some description.",
 			},
 		)
 		testUtil.NoError(t, addOptionErr)
 
 		parseErr := conf.Parse(ONE_STRING_JSON)
-		testUtil.WithError(t, parseErr)
+		testUtil.NoError(t, parseErr)
+
+		_, getErr := conf.GetInt("string")
+		testUtil.WithError(t, getErr)
+	})
+}
+
+func TestGetInt64(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "int64",
+				ValueType:   "int64",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(ONE_INT64_JSON)
+		testUtil.NoError(t, parseErr)
+
+		var expect int64 = 2147483648
+		actual, getErr := conf.GetInt64("int64")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, expect, actual)
+	})
+
+	t.Run("valid in object", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions([]configOption.Option{
+			{
+				Key:         "object",
+				ValueType:   "object",
+				Description: "some description.",
+			},
+			{
+				Key:         "object.int",
+				ValueType:   "int",
+				Description: "some description.",
+			},
+		})
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(ALL_IN_ONE_JSON)
+		testUtil.NoError(t, parseErr)
+
+		var expect int = 20
+		actual, getErr := conf.GetInt("object.int")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, expect, actual)
+	})
+
+	t.Run("invalid (string)", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "string",
+				ValueType:   "string",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(ONE_STRING_JSON)
+		testUtil.NoError(t, parseErr)
+
+		_, getErr := conf.GetInt("string")
+		testUtil.WithError(t, getErr)
+	})
+}
+
+
+
+func TestGetMap(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "limits",
+				ValueType:   "map<int>",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(MAPS_JSON)
+		testUtil.NoError(t, parseErr)
+
+		actual, getErr := conf.GetMap("limits")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, map[string]interface{}{"tenant-a": 100, "tenant-b": 200}, actual)
+
+		keys, getErr := conf.GetMapKeys("limits")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, []string{"tenant-a", "tenant-b"}, keys)
+
+		limit, getErr := conf.GetInt("limits.tenant-b")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, 200, limit)
+
+		_, getErr = conf.GetInt("limits.tenant-c")
+		testUtil.WithError(t, getErr)
+	})
+
+	t.Run("invalid (value type is int <-> value is string)", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "headers",
+				ValueType:   "map<int>",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(MAPS_JSON)
+		testUtil.WithError(t, parseErr)
+	})
+}
+
+func TestGetObjectMap(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions([]configOption.Option{
+			{
+				Key:         "plugins",
+				ValueType:   "map<object>",
+				Description: "some description.",
+			},
+			{
+				Key:          "plugins.enabled",
+				ValueType:    "bool",
+				Description:  "some description.",
+				DefaultValue: false,
+			},
+			{
+				Key:          "plugins.ttl",
+				ValueType:    "duration",
+				Description:  "some description.",
+				DefaultValue: time.Minute,
+			},
+		})
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(MAPS_JSON)
+		testUtil.NoError(t, parseErr)
+
+		plugins, getErr := conf.GetObjectMap("plugins")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, 2, len(plugins))
+
+		enabled, getErr := plugins["auth"].GetBool("enabled")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, true, enabled)
+
+		ttl, getErr := plugins["auth"].GetDuration("ttl")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, time.Minute, ttl)
+
+		ttl, getErr = plugins["cache"].GetDuration("ttl")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, 5*time.Minute, ttl)
+	})
+}
+
+func TestGetObject(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions([]configOption.Option{
+			{
+				Key:         "object",
+				ValueType:   "object",
+				Description: "some description.",
+			},
+			{
+				Key:         "object.key",
+				ValueType:   "string",
+				Description: "some description.",
+			},
+		})
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(ONE_OBJECT_JSON)
+		testUtil.NoError(t, parseErr)
+
+		childConf, getErr := conf.GetObject("object")
+		testUtil.NoError(t, getErr)
+
+		actual, getStringErr := childConf.GetString("key")
+		testUtil.NoError(t, getStringErr)
+
+		expect := "value"
+		testUtil.Match(t, expect, actual)
+	})
+}
+
+func TestGetObjectArray(t *testing.T) {
+	serverOpts := []configOption.Option{
+		{
+			Key:         "servers",
+			ValueType:   "array<object>",
+			Description: "some description.",
+			Required:    true,
+		},
+		{
+			Key:         "servers.host",
+			ValueType:   "string",
+			Description: "some description.",
+			Required:    true,
+		},
+		{
+			Key:         "servers.port",
+			ValueType:   "int",
+			Description: "some description.",
+			Required:    true,
+		},
+		{
+			Key:          "servers.weight",
+			ValueType:    "int",
+			Description:  "some description.",
+			DefaultValue: 1,
+		},
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions(serverOpts)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(SERVERS_JSON)
+		testUtil.NoError(t, parseErr)
+
+		servers, getErr := conf.GetObjectArray("servers")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, 2, len(servers))
+		if len(servers) == 2 {
+			host, getErr := servers[1].GetString("host")
+			testUtil.NoError(t, getErr)
+			testUtil.Match(t, "beta.example.com", host)
+
+			weight, getErr := servers[0].GetInt("weight")
+			testUtil.NoError(t, getErr)
+			testUtil.Match(t, 2, weight)
+
+			// default value is applied per element
+			weight, getErr = servers[1].GetInt("weight")
+			testUtil.NoError(t, getErr)
+			testUtil.Match(t, 1, weight)
+		}
+	})
+
+	t.Run("invalid (required option of an element is not provided)", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions(serverOpts)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.ParseBytes([]byte(`{"servers": [{"host": "alpha.example.com"}]}`), "json")
+		testUtil.WithError(t, parseErr)
+	})
+
+	t.Run("invalid (element is not an object)", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions(serverOpts)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.ParseBytes([]byte(`{"servers": ["alpha.example.com"]}`), "json")
+		testUtil.WithError(t, parseErr)
+	})
+}
+
+func TestGetString(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "string",
+				ValueType:   "string",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(ONE_STRING_JSON)
+		testUtil.NoError(t, parseErr)
+
+		var expect string = "some value"
+		actual, getErr := conf.GetString("string")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, expect, actual)
+	})
+
+	t.Run("valid in object", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions([]configOption.Option{
+			{
+				Key:         "object",
+				ValueType:   "object",
+				Description: "some description.",
+			},
+			{
+				Key:         "object.string",
+				ValueType:   "string",
+				Description: "some description.",
+			},
+		})
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(ALL_IN_ONE_JSON)
+		testUtil.NoError(t, parseErr)
+
+		var expect string = "some value in object"
+		actual, getErr := conf.GetString("object.string")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, expect, actual)
+	})
+
+	t.Run("invalid (int)", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "int",
+				ValueType:   "int",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(ONE_INT_JSON)
+		testUtil.NoError(t, parseErr)
+
+		_, getErr := conf.GetString("int")
+		testUtil.WithError(t, getErr)
+	})
+}
+
+func TestGetTime(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "time",
+				ValueType:   "time",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		expect := time.Date(2020, 3, 8, 12, 34, 56, 0, time.UTC)
+		parseErr := conf.ParseKeyValues(map[string]interface{}{"time": expect})
+		testUtil.NoError(t, parseErr)
+
+		actual, getErr := conf.GetTime("time")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, expect, actual)
+	})
+
+	t.Run("valid RFC 3339 string", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "time",
+				ValueType:   "time",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(UNITS_JSON)
+		testUtil.NoError(t, parseErr)
+
+		actual, getErr := conf.GetTime("time")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, true, actual.Equal(time.Date(2020, 3, 8, 3, 34, 56, 0, time.UTC)))
+	})
+
+	t.Run("invalid (string is not RFC 3339)", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "duration",
+				ValueType:   "time",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(UNITS_JSON)
+		testUtil.WithError(t, parseErr)
+	})
+
+	t.Run("invalid (string)", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "string",
+				ValueType:   "string",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(ONE_STRING_JSON)
+		testUtil.NoError(t, parseErr)
+
+		_, getErr := conf.GetTime("string")
+		testUtil.WithError(t, getErr)
+	})
+}
+
+func TestGetURL(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "url",
+				ValueType:   "url",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(NETWORK_JSON)
+		testUtil.NoError(t, parseErr)
+
+		actual, getErr := conf.GetURL("url")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, "https://example.com/api?key=value", actual.String())
+	})
+
+	t.Run("invalid (value is not url)", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "invalid",
+				ValueType:   "url",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(NETWORK_JSON)
+		testUtil.WithError(t, parseErr)
+	})
+}
+
+func TestParse(t *testing.T) {
+	t.Run("valid multi", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions([]configOption.Option{
+			{
+				Key:         "array",
+				ValueType:   "array",
+				Description: "some description.",
+			},
+			{
+				Key:         "float64",
+				ValueType:   "float64",
+				Description: "some description.",
+			},
+			{
+				Key:         "int",
+				ValueType:   "int",
+				Description: "some description.",
+			},
+			{
+				Key:         "string",
+				ValueType:   "string",
+				Description: "some description.",
+			},
+			{
+				Key:         "object",
+				ValueType:   "object",
+				Description: "some description.",
+			},
+			{
+				Key:         "object.array",
+				ValueType:   "array",
+				Description: "some description.",
+			},
+			{
+				Key:         "object.float64",
+				ValueType:   "float64",
+				Description: "some description.",
+			},
+			{
+				Key:         "object.int",
+				ValueType:   "int",
+				Description: "some description.",
+			},
+			{
+				Key:         "object.string",
+				ValueType:   "string",
+				Description: "some description.",
+			},
+		})
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(ALL_IN_ONE_JSON)
+		testUtil.NoError(t, parseErr)
+	})
+
+	t.Run("invalid (type is int <-> value is string)", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "string",
+				ValueType:   "int", // this should be string
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(ONE_STRING_JSON)
+		testUtil.WithError(t, parseErr)
+	})
+
+	t.Run("valid int64 bigger than 2^53", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "int64",
+				ValueType:   "int64",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(BIG_INT64_JSON)
+		testUtil.NoError(t, parseErr)
+
+		actual, getErr := conf.GetInt64("int64")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, int64(9007199254740993), actual)
+	})
+
+	t.Run("invalid (type is int <-> value is fractional)", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "float64",
+				ValueType:   "int", // this should be float64
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(ONE_FLOAT64_JSON)
+		testUtil.WithError(t, parseErr)
+	})
+
+	t.Run("invalid (value overflows int64)", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "int64",
+				ValueType:   "int64",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(OVERFLOW_INT64_JSON)
+		testUtil.WithError(t, parseErr)
+	})
+}
+
+func TestParseTypedArray(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions([]configOption.Option{
+			{
+				Key:         "ports",
+				ValueType:   "array<int>",
+				Description: "some description.",
+				MinLen:      1,
+			},
+			{
+				Key:         "tags",
+				ValueType:   "array<string>",
+				Description: "some description.",
+				MaxLen:      2,
+			},
+		})
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(TYPED_ARRAY_JSON)
+		testUtil.NoError(t, parseErr)
+
+		ports, getErr := conf.Get("ports")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, []interface{}{80, 443}, ports)
+
+		tags, getErr := conf.GetStringArray("tags")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, []string{"some", "value"}, tags)
+	})
+
+	t.Run("invalid (element type is string <-> value is int)", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "ports",
+				ValueType:   "array<string>",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(TYPED_ARRAY_JSON)
+		testUtil.WithError(t, parseErr)
+	})
+
+	t.Run("invalid (too many elements)", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "tags",
+				ValueType:   "array<string>",
+				Description: "some description.",
+				MaxLen:      1,
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(TYPED_ARRAY_JSON)
+		testUtil.WithError(t, parseErr)
+	})
+
+	t.Run("invalid (too few elements)", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "tags",
+				ValueType:   "array<string>",
+				Description: "some description.",
+				MinLen:      3,
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(TYPED_ARRAY_JSON)
+		testUtil.WithError(t, parseErr)
+	})
+}
+
+func TestParsePattern(t *testing.T) {
+	pluginOpts := []configOption.Option{
+		{
+			Key:         "plugins",
+			ValueType:   "object",
+			Description: "some description.",
+		},
+		{
+			Key:         "plugins.*.enabled",
+			ValueType:   "bool",
+			Description: "some description.",
+			Required:    true,
+		},
+		{
+			Key:          "plugins.*.path",
+			ValueType:    "string",
+			Description:  "some description.",
+			DefaultValue: "/usr/lib/plugins",
+		},
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions(pluginOpts)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(PLUGINS_JSON)
+		testUtil.NoError(t, parseErr)
+
+		enabled, getErr := conf.GetBool("plugins.auth.enabled")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, true, enabled)
+
+		path, getErr := conf.GetString("plugins.auth.path")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, "/opt/auth", path)
+
+		path, getErr = conf.GetString("plugins.cache.path")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, "/usr/lib/plugins", path)
+
+		_, getErr = conf.GetBool("plugins.unknown.enabled")
+		testUtil.WithError(t, getErr)
+	})
+
+	t.Run("invalid (required option of a pattern is not provided)", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions(pluginOpts)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.ParseBytes([]byte(`{"plugins": {"auth": {"path": "/opt/auth"}}}`), "json")
+		testUtil.WithError(t, parseErr)
+	})
+
+	t.Run("invalid (type is bool <-> value is string)", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions(pluginOpts)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.ParseBytes([]byte(`{"plugins": {"auth": {"enabled": "yes"}}}`), "json")
+		testUtil.WithError(t, parseErr)
+	})
+}
+
+func TestParseBytes(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "string",
+				ValueType:   "string",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.ParseBytes([]byte(`{"string": "some value"}`), "json")
+		testUtil.NoError(t, parseErr)
+
+		actual, getErr := conf.GetString("string")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, "some value", actual)
+	})
+
+	t.Run("invalid (broken json)", func(t *testing.T) {
+		var conf Config
+		parseErr := conf.ParseBytes([]byte(`{"string": `), "json")
+		testUtil.WithError(t, parseErr)
+	})
+}
+
+func TestParseFormat(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "int",
+				ValueType:   "int",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.ParseFormat(ONE_INT_JSON, "json")
+		testUtil.NoError(t, parseErr)
+
+		actual, getErr := conf.GetInt("int")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, 10, actual)
+	})
+
+	t.Run("invalid (format is not registered)", func(t *testing.T) {
+		var conf Config
+		parseErr := conf.ParseFormat(ONE_INT_JSON, "unknown")
+		testUtil.WithError(t, parseErr)
+	})
+}
+
+func TestParseFS(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "int",
+				ValueType:   "int",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		fsys := fstest.MapFS{
+			"defaults/config.json": &fstest.MapFile{Data: []byte(`{"int": 10}`)},
+		}
+		parseErr := conf.ParseFS(fsys, "defaults/config.json")
+		testUtil.NoError(t, parseErr)
+
+		actual, getErr := conf.GetInt("int")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, 10, actual)
+	})
+
+	t.Run("invalid (file does not exist)", func(t *testing.T) {
+		var conf Config
+		parseErr := conf.ParseFS(fstest.MapFS{}, "config.json")
+		testUtil.WithError(t, parseErr)
+	})
+}
+
+func TestParseKeyValues(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions([]configOption.Option{
+			{
+				Key:         "object",
+				ValueType:   "object",
+				Description: "some description.",
+			},
+			{
+				Key:         "object.int",
+				ValueType:   "int",
+				Description: "some description.",
+			},
+		})
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.ParseKeyValues(map[string]interface{}{
+			"object": map[string]interface{}{
+				"int": float64(10),
+			},
+		})
+		testUtil.NoError(t, parseErr)
+
+		actual, getErr := conf.GetInt("object.int")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, 10, actual)
+	})
+
+	t.Run("invalid (required option is not provided)", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "int",
+				ValueType:   "int",
+				Description: "some description.",
+				Required:    true,
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.ParseKeyValues(map[string]interface{}{})
+		testUtil.WithError(t, parseErr)
+	})
+}
+
+func TestParseReader(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "float64",
+				ValueType:   "float64",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.ParseReader(strings.NewReader(`{"float64": 1.2}`), "json")
+		testUtil.NoError(t, parseErr)
+
+		actual, getErr := conf.GetFloat64("float64")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, 1.2, actual)
+	})
+
+	t.Run("invalid (format is not registered)", func(t *testing.T) {
+		var conf Config
+		parseErr := conf.ParseReader(strings.NewReader(`{}`), "unknown")
+		testUtil.WithError(t, parseErr)
+	})
+}
+
+func TestString(t *testing.T) {
+	t.Run("default values", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions([]configOption.Option{
+			{
+				Key:          "bool",
+				ValueType:    "bool",
+				Description:  "some bool.",
+				DefaultValue: true,
+			},
+			{
+				Key:          "int",
+				ValueType:    "int",
+				Description:  "some int.",
+				DefaultValue: 10,
+			},
+			{
+				Key:          "string",
+				ValueType:    "string",
+				Description:  "some string.",
+				DefaultValue: "some value",
+			},
+			{
+				Key:         "time",
+				ValueType:   "time",
+				Description: "some time.",
+				Required:    true,
+			},
+		})
+		testUtil.NoError(t, addOptionErr)
+
+		expect := "Following options are avairable.\n" +
+			"  bool  : some bool. (default: true)\n" +
+			"  int   : some int. (default: 10)\n" +
+			"  string: some string. (default: \"some value\")\n" +
+			"  time  : some time. (required)\n"
+		testUtil.Match(t, expect, conf.String())
+	})
+
+	t.Run("allowed values", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:           "level",
+				ValueType:     "string",
+				Description:   "log level.",
+				DefaultValue:  "info",
+				AllowedValues: []interface{}{"debug", "info", "warn"},
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		expect := "Following options are avairable.\n" +
+			"  level: log level. (one of: debug, info, warn) (default: \"info\")\n"
+		testUtil.Match(t, expect, conf.String())
 	})
 }
 
@@ -0,0 +1,244 @@
+package config
+
+/*
+ * Module Dependencies
+ */
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+/*
+ * Types
+ */
+
+// Decoder decodes the content of a config file into key values accepted by
+// Config.ParseKeyValues.
+type Decoder interface {
+	Decode(raw []byte) (map[string]interface{}, error)
+}
+
+// DecoderFunc adapts an ordinary function to the Decoder interface.
+type DecoderFunc func(raw []byte) (map[string]interface{}, error)
+
+// PositionDecoder is a Decoder which also reports the byte offset of every
+// key, so that errors can point at the line of the file causing them.
+// Offsets are keyed by key path, e.g. "object.string" or "servers[1].port",
+// and malformed content is reported as a *SyntaxError.
+type PositionDecoder interface {
+	Decoder
+	DecodeWithOffsets(raw []byte) (map[string]interface{}, map[string]int, error)
+}
+
+// SyntaxError is a malformed content detected at byte offset Offset.
+type SyntaxError struct {
+	Offset int
+	Msg    string
+}
+
+type jsonDecoder struct{}
+
+/*
+ * Constants and Package Scope Variables
+ */
+
+const DEFAULT_FORMAT string = "json"
+
+var (
+	decodersMutex sync.RWMutex
+	// format name -> decoder
+	decoders = make(map[string]Decoder)
+	// file extension -> format name
+	formatsByExt = make(map[string]string)
+)
+
+/*
+ * Package Private Functions
+ */
+
+func init() {
+	RegisterDecoder(DEFAULT_FORMAT, jsonDecoder{}, ".json")
+}
+
+// nextTokenOffset skips whitespace and separators from offset, so that the
+// offset of a key or an element is where its token starts.
+func nextTokenOffset(raw []byte, offset int64) int {
+	index := int(offset)
+	for index < len(raw) && strings.IndexByte(" \t\r\n,:", raw[index]) >= 0 {
+		index++
+	}
+	return index
+}
+
+// toSyntaxError converts an error of json.Decoder into a *SyntaxError.
+func toSyntaxError(raw []byte, err error) error {
+	var jsonSyntaxErr *json.SyntaxError
+	if errors.As(err, &jsonSyntaxErr) {
+		// Offset of json.SyntaxError may be that of the separator before
+		// the invalid character.
+		offset := jsonSyntaxErr.Offset - 1
+		if offset < 0 {
+			offset = 0
+		}
+		return &SyntaxError{Offset: nextTokenOffset(raw, offset), Msg: jsonSyntaxErr.Error()}
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return &SyntaxError{Offset: len(raw), Msg: "unexpected end of JSON input"}
+	}
+	return err
+}
+
+// walkJson decodes the value starting at the next token, recording the
+// offsets of keys and elements in it. Numbers are kept as json.Number, so
+// that int64 options don't lose precision by going through float64.
+func walkJson(decoder *json.Decoder, raw []byte, path string, offsets map[string]int) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return token, nil
+	}
+	if delim == '[' {
+		array := []interface{}{}
+		for index := 0; decoder.More(); index++ {
+			elemPath := fmt.Sprintf("%v[%v]", path, index)
+			offsets[elemPath] = nextTokenOffset(raw, decoder.InputOffset())
+			elem, err := walkJson(decoder, raw, elemPath, offsets)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, elem)
+		}
+		// Consume "]".
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		return array, nil
+	}
+	object := make(map[string]interface{})
+	for decoder.More() {
+		offset := nextTokenOffset(raw, decoder.InputOffset())
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := token.(string)
+		keyPath := joinKey(path, key)
+		offsets[keyPath] = offset
+		value, err := walkJson(decoder, raw, keyPath, offsets)
+		if err != nil {
+			return nil, err
+		}
+		object[key] = value
+	}
+	// Consume "}".
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+	return object, nil
+}
+
+func findDecoder(format string) (Decoder, error) {
+	decodersMutex.RLock()
+	defer decodersMutex.RUnlock()
+	decoder, ok := decoders[strings.ToLower(format)]
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("Decoder for format \"%v\" is not registered.", format))
+	}
+	return decoder, nil
+}
+
+// formatOf returns the format registered for the extension of path.
+// Files with an unknown extension are treated as DEFAULT_FORMAT.
+func formatOf(path string) string {
+	decodersMutex.RLock()
+	defer decodersMutex.RUnlock()
+	format, ok := formatsByExt[strings.ToLower(filepath.Ext(path))]
+	if !ok {
+		return DEFAULT_FORMAT
+	}
+	return format
+}
+
+/*
+ * Public Functions
+ */
+
+func (f DecoderFunc) Decode(raw []byte) (map[string]interface{}, error) {
+	return f(raw)
+}
+
+func (jsonDecoder) Decode(raw []byte) (map[string]interface{}, error) {
+	keyValues, _, err := jsonDecoder{}.DecodeWithOffsets(raw)
+	return keyValues, err
+}
+
+func (jsonDecoder) DecodeWithOffsets(raw []byte) (map[string]interface{}, map[string]int, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	offsets := make(map[string]int)
+	value, err := walkJson(decoder, raw, "", offsets)
+	if err != nil {
+		return nil, nil, toSyntaxError(raw, err)
+	}
+	// Reject trailing data, as json.Unmarshal does.
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, nil, &SyntaxError{
+			Offset: nextTokenOffset(raw, decoder.InputOffset()),
+			Msg:    "Invalid data after top-level value.",
+		}
+	}
+	if value == nil {
+		return make(map[string]interface{}), offsets, nil
+	}
+	keyValues, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, nil, &SyntaxError{
+			Offset: nextTokenOffset(raw, 0),
+			Msg:    fmt.Sprintf("Top-level value must be an object. But it is %T.", value),
+		}
+	}
+	return keyValues, offsets, nil
+}
+
+func (err *SyntaxError) Error() string {
+	return err.Msg
+}
+
+// Formats returns the names of all registered formats.
+func Formats() []string {
+	decodersMutex.RLock()
+	defer decodersMutex.RUnlock()
+	var formats []string
+	for format := range decoders {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// RegisterDecoder makes a decoder available to Parse by format name and by
+// the given file extensions (e.g. ".yaml"). Registering the same format or
+// extension again replaces the previous one.
+func RegisterDecoder(format string, decoder Decoder, exts ...string) {
+	decodersMutex.Lock()
+	defer decodersMutex.Unlock()
+	format = strings.ToLower(format)
+	decoders[format] = decoder
+	for _, ext := range exts {
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		formatsByExt[strings.ToLower(ext)] = format
+	}
+}
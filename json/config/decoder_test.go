@@ -0,0 +1,98 @@
+package config
+
+/*
+ * Module Dependencies
+ */
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/mozzzzy/config/json/configOption"
+	"github.com/mozzzzy/testUtil"
+)
+
+/*
+ * Types
+ */
+
+/*
+ * Constants and Package Scope Variables
+ */
+
+const ONE_INT_KV string = "testData/one_int.kv"
+
+/*
+ * Functions
+ */
+
+// decodeKv decodes "key=number" lines for tests of the decoder registry.
+func decodeKv(raw []byte) (map[string]interface{}, error) {
+	keyValues := make(map[string]interface{})
+	for _, line := range strings.Split(strings.TrimSpace(string(raw)), "\n") {
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) != 2 {
+			return nil, errors.New("invalid line " + line)
+		}
+		flt64, err := strconv.ParseFloat(kv[1], 64)
+		if err != nil {
+			return nil, err
+		}
+		keyValues[kv[0]] = flt64
+	}
+	return keyValues, nil
+}
+
+func TestRegisterDecoder(t *testing.T) {
+	t.Run("parse by extension", func(t *testing.T) {
+		RegisterDecoder("kv", DecoderFunc(decodeKv), "kv")
+
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "int",
+				ValueType:   "int",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(ONE_INT_KV)
+		testUtil.NoError(t, parseErr)
+
+		actual, getErr := conf.GetInt("int")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, 10, actual)
+	})
+
+	t.Run("parse by format name", func(t *testing.T) {
+		RegisterDecoder("kv", DecoderFunc(decodeKv))
+
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "int",
+				ValueType:   "int",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.ParseFormat(ONE_INT_KV, "KV")
+		testUtil.NoError(t, parseErr)
+	})
+}
+
+func TestFormats(t *testing.T) {
+	t.Run("json is registered", func(t *testing.T) {
+		found := false
+		for _, format := range Formats() {
+			if format == DEFAULT_FORMAT {
+				found = true
+			}
+		}
+		testUtil.Match(t, true, found)
+	})
+}
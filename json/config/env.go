@@ -0,0 +1,98 @@
+package config
+
+/*
+ * Module Dependencies
+ */
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mozzzzy/config/json/configOption"
+)
+
+/*
+ * Types
+ */
+
+/*
+ * Constants and Package Scope Variables
+ */
+
+/*
+ * Package Private Functions
+ */
+
+func envName(prefix string, key string) string {
+	name := strings.Map(func(r rune) rune {
+		if ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') {
+			return r
+		}
+		return '_'
+	}, strings.ToUpper(key))
+	if prefix == "" {
+		return name
+	}
+	return strings.ToUpper(prefix) + "_" + name
+}
+
+func (conf *Config) parseEnv(prefix string) error {
+	var errs ValidationErrors
+	for index := 0; index < len(conf.options); index++ {
+		opt := &conf.options[index]
+		if opt.ValueType == "nil" || opt.ValueType == "object" ||
+			opt.ValueType == "array<object>" || opt.ValueType == "map<object>" ||
+			conf.isElemOption(opt.Key) || isPattern(opt.Key) {
+			continue
+		}
+		name := envName(prefix, opt.Key)
+		str, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+		value, err := parseString(opt, str)
+		if err != nil {
+			errs.add(opt.Key, errors.New(fmt.Sprintf(
+				"Invalid environment variable %v for %v. %v", name, opt.Key, err)))
+			continue
+		}
+		origin := configOption.Origin{Kind: configOption.ORIGIN_ENV, Name: name}
+		if err := opt.SetValueFrom(value, origin); err != nil {
+			errs.add(opt.Key, err)
+			continue
+		}
+		delete(conf.locations, opt.Key)
+		errs.add(opt.Key, conf.setParents(opt.Key, origin))
+	}
+	return errs.err()
+}
+
+/*
+ * Public Functions
+ */
+
+// BindEnv makes Parse and its variants overlay an environment variable for
+// every declared option. Environment variables take precedence over values
+// in the parsed file, which take precedence over default values.
+// See EnvName for how variable names are derived from option keys.
+func (conf *Config) BindEnv(prefix string) {
+	conf.envBound = true
+	conf.envPrefix = prefix
+}
+
+// EnvName returns the environment variable name read for the option key.
+// The key is upper-cased, and every character other than letters and digits
+// is replaced with "_". e.g. "object.int" with prefix "APP" is
+// "APP_OBJECT_INT".
+func (conf Config) EnvName(key string) string {
+	return envName(conf.envPrefix, key)
+}
+
+// ParseEnv sets options only from environment variables, for programs
+// configured without any file.
+func (conf *Config) ParseEnv(prefix string) error {
+	conf.BindEnv(prefix)
+	return conf.ParseKeyValues(map[string]interface{}{})
+}
@@ -0,0 +1,210 @@
+package config
+
+/*
+ * Module Dependencies
+ */
+
+import (
+	"os"
+	"testing"
+
+	"github.com/mozzzzy/config/json/configOption"
+	"github.com/mozzzzy/testUtil"
+)
+
+/*
+ * Types
+ */
+
+/*
+ * Constants and Package Scope Variables
+ */
+
+/*
+ * Functions
+ */
+
+func TestBindEnv(t *testing.T) {
+	t.Run("environment variable overrides file", func(t *testing.T) {
+		os.Setenv("APP_INT", "20")
+		defer os.Unsetenv("APP_INT")
+
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "int",
+				ValueType:   "int",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		conf.BindEnv("APP")
+		parseErr := conf.Parse(ONE_INT_JSON)
+		testUtil.NoError(t, parseErr)
+
+		actual, getErr := conf.GetInt("int")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, 20, actual)
+	})
+
+	t.Run("file is used without environment variable", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "int",
+				ValueType:   "int",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		conf.BindEnv("APP_NOT_EXIST")
+		parseErr := conf.Parse(ONE_INT_JSON)
+		testUtil.NoError(t, parseErr)
+
+		actual, getErr := conf.GetInt("int")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, 10, actual)
+	})
+
+	t.Run("invalid (type is int <-> value is string)", func(t *testing.T) {
+		os.Setenv("APP_INT", "abc")
+		defer os.Unsetenv("APP_INT")
+
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "int",
+				ValueType:   "int",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		conf.BindEnv("APP")
+		parseErr := conf.Parse(ONE_INT_JSON)
+		testUtil.WithError(t, parseErr)
+	})
+}
+
+func TestEnvName(t *testing.T) {
+	t.Run("with prefix", func(t *testing.T) {
+		var conf Config
+		conf.BindEnv("app")
+		testUtil.Match(t, "APP_OBJECT_INT", conf.EnvName("object.int"))
+	})
+
+	t.Run("without prefix", func(t *testing.T) {
+		var conf Config
+		testUtil.Match(t, "OBJECT_SOME_KEY", conf.EnvName("object.some-key"))
+	})
+}
+
+func TestParseEnv(t *testing.T) {
+	t.Run("valid multi", func(t *testing.T) {
+		os.Setenv("APP_ARRAY", `["some", "value"]`)
+		defer os.Unsetenv("APP_ARRAY")
+		os.Setenv("APP_OBJECT_FLOAT64", "1.2")
+		defer os.Unsetenv("APP_OBJECT_FLOAT64")
+		os.Setenv("APP_OBJECT_INT64", "9007199254740993")
+		defer os.Unsetenv("APP_OBJECT_INT64")
+		os.Setenv("APP_OBJECT_STRING", "some value")
+		defer os.Unsetenv("APP_OBJECT_STRING")
+		os.Setenv("APP_OBJECT_TIME", "2020-03-08T12:34:56Z")
+		defer os.Unsetenv("APP_OBJECT_TIME")
+
+		var conf Config
+		addOptionErr := conf.AddOptions([]configOption.Option{
+			{
+				Key:         "array",
+				ValueType:   "array",
+				Description: "some description.",
+			},
+			{
+				Key:         "object",
+				ValueType:   "object",
+				Description: "some description.",
+				Required:    true,
+			},
+			{
+				Key:         "object.float64",
+				ValueType:   "float64",
+				Description: "some description.",
+			},
+			{
+				Key:         "object.int64",
+				ValueType:   "int64",
+				Description: "some description.",
+			},
+			{
+				Key:         "object.string",
+				ValueType:   "string",
+				Description: "some description.",
+			},
+			{
+				Key:         "object.time",
+				ValueType:   "time",
+				Description: "some description.",
+			},
+		})
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.ParseEnv("APP")
+		testUtil.NoError(t, parseErr)
+
+		actualAry, getAryErr := conf.GetStringArray("array")
+		testUtil.NoError(t, getAryErr)
+		testUtil.Match(t, []string{"some", "value"}, actualAry)
+
+		actualFlt64, getFlt64Err := conf.GetFloat64("object.float64")
+		testUtil.NoError(t, getFlt64Err)
+		testUtil.Match(t, 1.2, actualFlt64)
+
+		actualInt64, getInt64Err := conf.GetInt64("object.int64")
+		testUtil.NoError(t, getInt64Err)
+		testUtil.Match(t, int64(9007199254740993), actualInt64)
+
+		actualStr, getStrErr := conf.GetString("object.string")
+		testUtil.NoError(t, getStrErr)
+		testUtil.Match(t, "some value", actualStr)
+
+		actualTime, getTimeErr := conf.GetTime("object.time")
+		testUtil.NoError(t, getTimeErr)
+		testUtil.Match(t, 2020, actualTime.Year())
+	})
+
+	t.Run("invalid (required option is not provided)", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "int",
+				ValueType:   "int",
+				Description: "some description.",
+				Required:    true,
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.ParseEnv("APP_NOT_EXIST")
+		testUtil.WithError(t, parseErr)
+	})
+
+	t.Run("invalid (array is not json)", func(t *testing.T) {
+		os.Setenv("APP_ARRAY", "some,value")
+		defer os.Unsetenv("APP_ARRAY")
+
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "array",
+				ValueType:   "array",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.ParseEnv("APP")
+		testUtil.WithError(t, parseErr)
+	})
+}
@@ -0,0 +1,170 @@
+package config
+
+/*
+ * Module Dependencies
+ */
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mozzzzy/config/json/configOption"
+)
+
+/*
+ * Types
+ */
+
+// ValidationError is a failure of the option at the dotted key path Key,
+// e.g. "servers[1].host". Key is empty for failures not related to an
+// option, such as a missing file. Pos and Snippet are set for failures
+// located in a file by a PositionDecoder, and Snippet shows the line of Pos
+// with a caret under Column.
+type ValidationError struct {
+	Key     string
+	Err     error
+	Pos     *Position
+	Snippet string
+}
+
+// TypeMismatchError is returned when a value is not of the expected type,
+// by getters, Parse and its variants.
+type TypeMismatchError = configOption.TypeMismatchError
+
+// ValidationErrors reports all failures found by Parse and its variants,
+// Load and Validate in one pass. Use errors.As to inspect each failure.
+type ValidationErrors []*ValidationError
+
+/*
+ * Constants and Package Scope Variables
+ */
+
+// Errors of configOption, usable with errors.Is on errors of this package.
+var (
+	ErrKeyNotFound     = configOption.ErrKeyNotFound
+	ErrRequiredMissing = configOption.ErrRequiredMissing
+	ErrDuplicate       = configOption.ErrDuplicate
+	ErrNoValue         = configOption.ErrNoValue
+)
+
+/*
+ * Package Private Functions
+ */
+
+// isRelatedKey reports whether a and b are the same key, or one of them
+// contains the other, e.g. "servers" and "servers[1].host".
+func isRelatedKey(a string, b string) bool {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	return a == b ||
+		strings.HasPrefix(b, a+".") || strings.HasPrefix(b, a+"[")
+}
+
+// add appends err as the failure of key. The failures in ValidationErrors
+// keep their own keys. Only the first failure of related keys is kept, so
+// that e.g. a type mismatch isn't reported again as a missing required
+// option.
+func (errs *ValidationErrors) add(key string, err error) {
+	added := &ValidationError{Key: key, Err: err}
+	switch e := err.(type) {
+	case nil:
+		return
+	case ValidationErrors:
+		for _, validationErr := range e {
+			errs.add(validationErr.Key, validationErr)
+		}
+		return
+	case *ValidationError:
+		copied := *e
+		key, added = e.Key, &copied
+	}
+	if key != "" {
+		for _, validationErr := range *errs {
+			if validationErr.Key != "" && isRelatedKey(validationErr.Key, key) {
+				return
+			}
+		}
+	}
+	*errs = append(*errs, added)
+}
+
+// addPrefixed appends err as add does, prefixing its keys with prefix.
+func (errs *ValidationErrors) addPrefixed(prefix string, err error) {
+	var prefixed ValidationErrors
+	prefixed.add("", err)
+	for _, validationErr := range prefixed {
+		validationErr.Key = joinKey(prefix, validationErr.Key)
+		errs.add(validationErr.Key, validationErr)
+	}
+}
+
+// err returns errs as an error, or nil if errs is empty.
+func (errs ValidationErrors) err() error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+/*
+ * Public Functions
+ */
+
+func (err *ValidationError) Error() string {
+	msg := err.Err.Error()
+	if err.Key != "" {
+		msg = fmt.Sprintf("%v: %v", err.Key, msg)
+	}
+	if err.Pos != nil {
+		msg = fmt.Sprintf("%v: %v", err.Pos, msg)
+	}
+	if err.Snippet != "" {
+		msg += "\n" + err.Snippet
+	}
+	return msg
+}
+
+func (err *ValidationError) Unwrap() error {
+	return err.Err
+}
+
+// As lets errors.As look into each failure. It finds the first failure
+// matching target.
+func (errs ValidationErrors) As(target interface{}) bool {
+	for _, err := range errs {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (errs ValidationErrors) Error() string {
+	var msgs []string
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Is lets errors.Is look into each failure.
+func (errs ValidationErrors) Is(target error) bool {
+	for _, err := range errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Unwrap lets errors.Is and errors.As of Go 1.20 or later look into each
+// failure. Is and As do the same for earlier versions.
+func (errs ValidationErrors) Unwrap() []error {
+	var unwrapped []error
+	for _, err := range errs {
+		unwrapped = append(unwrapped, err)
+	}
+	return unwrapped
+}
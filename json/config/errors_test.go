@@ -0,0 +1,337 @@
+package config
+
+/*
+ * Module Dependencies
+ */
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/mozzzzy/config/json/configOption"
+	"github.com/mozzzzy/config/validator"
+	"github.com/mozzzzy/testUtil"
+)
+
+/*
+ * Types
+ */
+
+/*
+ * Constants and Package Scope Variables
+ */
+
+/*
+ * Functions
+ */
+
+func keysOf(errs ValidationErrors) []string {
+	var keys []string
+	for _, err := range errs {
+		keys = append(keys, err.Key)
+	}
+	return keys
+}
+
+func TestValidationErrors(t *testing.T) {
+	opts := []configOption.Option{
+		{
+			Key:            "int",
+			ValueType:      "int",
+			Description:    "some description.",
+			Validator:      validator.IntSmallerThan,
+			ValidatorParam: 10,
+		},
+		{
+			Key:         "required",
+			ValueType:   "string",
+			Description: "some description.",
+			Required:    true,
+		},
+		{
+			Key:         "servers",
+			ValueType:   "array<object>",
+			Description: "some description.",
+		},
+		{
+			Key:         "servers.port",
+			ValueType:   "int",
+			Description: "some description.",
+			Required:    true,
+		},
+		{
+			Key:         "string",
+			ValueType:   "string",
+			Description: "some description.",
+		},
+	}
+
+	t.Run("all failures are reported", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions(opts)
+		testUtil.NoError(t, addOptionErr)
+
+		conf.SetStrict(true)
+		parseErr := conf.ParseBytes([]byte(`{
+			"int": 20,
+			"servers": [{"port": 80}, {}, {"port": "80"}],
+			"string": 10,
+			"strng": "some value"
+		}`), "json")
+		testUtil.WithError(t, parseErr)
+
+		var validationErrs ValidationErrors
+		testUtil.Match(t, true, errors.As(parseErr, &validationErrs))
+		testUtil.Match(t, []string{
+			"strng",
+			"servers[1].port",
+			"servers[2].port",
+			"string",
+			"int",
+			"required",
+		}, keysOf(validationErrs))
+	})
+
+	t.Run("failure of an option is reported once", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "required",
+				ValueType:   "string",
+				Description: "some description.",
+				Required:    true,
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.ParseBytes([]byte(`{"required": 10}`), "json")
+		var validationErrs ValidationErrors
+		testUtil.Match(t, true, errors.As(parseErr, &validationErrs))
+		testUtil.Match(t, []string{"required"}, keysOf(validationErrs))
+	})
+
+	t.Run("Validate reports all failures", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions([]configOption.Option{
+			{
+				Key:         "a",
+				ValueType:   "int",
+				Description: "some description.",
+				Required:    true,
+			},
+			{
+				Key:         "b",
+				ValueType:   "int",
+				Description: "some description.",
+				Required:    true,
+			},
+		})
+		testUtil.NoError(t, addOptionErr)
+
+		validateErr := conf.Validate()
+		var validationErrs ValidationErrors
+		testUtil.Match(t, true, errors.As(validateErr, &validationErrs))
+		testUtil.Match(t, []string{"a", "b"}, keysOf(validationErrs))
+		testUtil.Match(t,
+			"a: Required option is not provided: a\nb: Required option is not provided: b",
+			validateErr.Error())
+	})
+
+	t.Run("no failure", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions(opts)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.ParseBytes([]byte(`{"int": 5, "required": "some value"}`), "json")
+		testUtil.NoError(t, parseErr)
+	})
+}
+
+func TestSentinelErrors(t *testing.T) {
+	t.Run("key not found", func(t *testing.T) {
+		var conf Config
+		_, getErr := conf.GetInt("not_exist")
+		testUtil.Match(t, true, errors.Is(getErr, ErrKeyNotFound))
+
+		_, getErr = conf.GetObject("not_exist")
+		testUtil.Match(t, true, errors.Is(getErr, ErrKeyNotFound))
+	})
+
+	t.Run("index out of range", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "ports",
+				ValueType:   "array<int>",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(TYPED_ARRAY_JSON)
+		testUtil.NoError(t, parseErr)
+
+		_, getErr := conf.GetInt("ports[2]")
+		testUtil.Match(t, true, errors.Is(getErr, ErrKeyNotFound))
+	})
+
+	t.Run("type mismatch of getter", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "int",
+				ValueType:   "int",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(ONE_INT_JSON)
+		testUtil.NoError(t, parseErr)
+
+		_, getErr := conf.GetString("int")
+		var typeMismatchErr *TypeMismatchError
+		testUtil.Match(t, true, errors.As(getErr, &typeMismatchErr))
+		if typeMismatchErr != nil {
+			testUtil.Match(t, TypeMismatchError{Key: "int", Want: "string", Got: "int"}, *typeMismatchErr)
+		}
+	})
+
+	t.Run("type mismatch of Parse", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "port",
+				ValueType:   "int",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.ParseBytes([]byte(`{"port": "80"}`), "json")
+		var typeMismatchErr *TypeMismatchError
+		testUtil.Match(t, true, errors.As(parseErr, &typeMismatchErr))
+		if typeMismatchErr != nil {
+			testUtil.Match(t, "int", typeMismatchErr.Want)
+			testUtil.Match(t, "string", typeMismatchErr.Got)
+		}
+	})
+
+	t.Run("type mismatch of Parse reports json kind", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "name",
+				ValueType:   "string",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.ParseBytes([]byte(`{"name": 80}`), "json")
+		var typeMismatchErr *TypeMismatchError
+		testUtil.Match(t, true, errors.As(parseErr, &typeMismatchErr))
+		if typeMismatchErr != nil {
+			testUtil.Match(t, "number", typeMismatchErr.Got)
+		}
+	})
+
+	t.Run("type mismatch of nested Parse reports key path", func(t *testing.T) {
+		var conf Config
+		addOptionsErr := conf.AddOptions([]configOption.Option{
+			{Key: "o", ValueType: "object", Description: "some description."},
+			{Key: "o.x", ValueType: "int", Description: "some description."},
+			{Key: "o.y", ValueType: "array<int>", Description: "some description."},
+		})
+		testUtil.NoError(t, addOptionsErr)
+
+		parseErr := conf.ParseBytes([]byte(`{"o": {"x": "s", "y": ["a"]}}`), "json")
+		var validationErrs ValidationErrors
+		testUtil.Match(t, true, errors.As(parseErr, &validationErrs))
+		var keys []string
+		for _, validationErr := range validationErrs {
+			var typeMismatchErr *TypeMismatchError
+			if errors.As(validationErr.Err, &typeMismatchErr) {
+				keys = append(keys, typeMismatchErr.Key)
+			}
+		}
+		sort.Strings(keys)
+		testUtil.Match(t, []string{"o.x", "o.y[0]"}, keys)
+	})
+
+	t.Run("type mismatch of array element", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "array",
+				ValueType:   "array",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.ParseBytes([]byte(`{"array": [1, "a"]}`), "json")
+		testUtil.NoError(t, parseErr)
+
+		_, getErr := conf.GetInt64Array("array")
+		var typeMismatchErr *TypeMismatchError
+		testUtil.Match(t, true, errors.As(getErr, &typeMismatchErr))
+		if typeMismatchErr != nil {
+			testUtil.Match(t, TypeMismatchError{Key: "array[1]", Want: "int64", Got: "string"}, *typeMismatchErr)
+		}
+
+		_, getErr = conf.GetIntArray("array")
+		testUtil.Match(t, true, errors.As(getErr, &typeMismatchErr))
+	})
+
+	t.Run("required option is missing", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "int",
+				ValueType:   "int",
+				Description: "some description.",
+				Required:    true,
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.ParseBytes([]byte(`{}`), "json")
+		testUtil.Match(t, true, errors.Is(parseErr, ErrRequiredMissing))
+	})
+
+	t.Run("duplicate definition", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "int",
+				ValueType:   "int",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(ONE_INT_JSON)
+		testUtil.NoError(t, parseErr)
+
+		parseErr = conf.Parse(ONE_INT_JSON)
+		testUtil.Match(t, true, errors.Is(parseErr, ErrDuplicate))
+	})
+
+	t.Run("Is and As without Unwrap of Go 1.20", func(t *testing.T) {
+		validationErrs := ValidationErrors{
+			{Key: "int", Err: &TypeMismatchError{Key: "int", Want: "int", Got: "string"}},
+			{Key: "string", Err: fmt.Errorf("%w: string", ErrRequiredMissing)},
+		}
+		testUtil.Match(t, true, validationErrs.Is(ErrRequiredMissing))
+		testUtil.Match(t, false, validationErrs.Is(ErrDuplicate))
+
+		var typeMismatchErr *TypeMismatchError
+		testUtil.Match(t, true, validationErrs.As(&typeMismatchErr))
+		if typeMismatchErr != nil {
+			testUtil.Match(t, "string", typeMismatchErr.Got)
+		}
+	})
+}
@@ -0,0 +1,160 @@
+package config
+
+/*
+ * Module Dependencies
+ */
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/mozzzzy/config/json/configOption"
+)
+
+/*
+ * Types
+ */
+
+// optionFlag is the flag.Value registered by BindFlags for one option.
+type optionFlag struct {
+	opt   configOption.Option
+	str   string
+	value interface{}
+	isSet bool
+}
+
+/*
+ * Constants and Package Scope Variables
+ */
+
+/*
+ * Package Private Functions
+ */
+
+// formatValue formats value so that parseString can parse it back.
+func formatValue(valueType string, value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	// Durations, times and urls are formatted as strings, so that Set can
+	// parse the elements again.
+	if elemType, ok := configOption.ElementType(valueType); ok {
+		ary, _ := value.([]interface{})
+		elems := make([]interface{}, 0, len(ary))
+		for _, elem := range ary {
+			switch elemType {
+			case "duration", "time", "url":
+				elems = append(elems, formatValue(elemType, elem))
+			default:
+				elems = append(elems, elem)
+			}
+		}
+		value = elems
+		valueType = "array"
+	}
+	if mapValueType, ok := configOption.MapValueType(valueType); ok {
+		mp, _ := value.(map[string]interface{})
+		values := make(map[string]interface{}, len(mp))
+		for mapKey, mapValue := range mp {
+			switch mapValueType {
+			case "duration", "time", "url":
+				values[mapKey] = formatValue(mapValueType, mapValue)
+			default:
+				values[mapKey] = mapValue
+			}
+		}
+		value = values
+		valueType = "map"
+	}
+	switch valueType {
+	case "array", "map":
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Sprint(value)
+		}
+		return string(raw)
+	case "time":
+		tm, ok := value.(time.Time)
+		if ok {
+			return tm.Format(time.RFC3339)
+		}
+	}
+	return fmt.Sprint(value)
+}
+
+func (f *optionFlag) String() string {
+	if f == nil {
+		return ""
+	}
+	return f.str
+}
+
+// IsBoolFlag lets bool options be given as -key without a value.
+func (f *optionFlag) IsBoolFlag() bool {
+	return f.opt.ValueType == "bool"
+}
+
+func (f *optionFlag) Set(str string) error {
+	value, err := parseString(&f.opt, str)
+	if err != nil {
+		return err
+	}
+	f.str = str
+	f.value = value
+	f.isSet = true
+	return nil
+}
+
+func (conf *Config) parseFlags() error {
+	var errs ValidationErrors
+	for index := 0; index < len(conf.options); index++ {
+		opt := &conf.options[index]
+		f, ok := conf.flags[opt.Key]
+		if !ok || !f.isSet {
+			continue
+		}
+		origin := configOption.Origin{Kind: configOption.ORIGIN_FLAG, Name: opt.Key}
+		if err := opt.SetValueFrom(f.value, origin); err != nil {
+			errs.add(opt.Key, errors.New(fmt.Sprintf("Invalid flag -%v. %v", opt.Key, err)))
+			continue
+		}
+		delete(conf.locations, opt.Key)
+		errs.add(opt.Key, conf.setParents(opt.Key, origin))
+	}
+	return errs.err()
+}
+
+/*
+ * Public Functions
+ */
+
+// BindFlags defines a flag named after the key of every declared option
+// (e.g. -object.int or --object.int) in flagSet. Option descriptions and
+// default values are shown in the usage message.
+// Flags parsed by flagSet before Parse take precedence over environment
+// variables and the parsed file.
+func (conf *Config) BindFlags(flagSet *flag.FlagSet) {
+	if conf.flags == nil {
+		conf.flags = make(map[string]*optionFlag)
+	}
+	for _, opt := range conf.options {
+		if opt.ValueType == "nil" || opt.ValueType == "object" ||
+			opt.ValueType == "array<object>" || opt.ValueType == "map<object>" ||
+			conf.isElemOption(opt.Key) || isPattern(opt.Key) {
+			continue
+		}
+		f := &optionFlag{
+			opt: opt,
+			str: formatValue(opt.ValueType, opt.DefaultValue),
+		}
+		usage := opt.Description
+		if len(opt.AllowedValues) > 0 {
+			usage += " (one of: " + formatAllowedValues(opt) + ")"
+		}
+		conf.flags[opt.Key] = f
+		flagSet.Var(f, opt.Key, usage)
+	}
+}
@@ -0,0 +1,178 @@
+package config
+
+/*
+ * Module Dependencies
+ */
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/mozzzzy/config/json/configOption"
+	"github.com/mozzzzy/testUtil"
+)
+
+/*
+ * Types
+ */
+
+/*
+ * Constants and Package Scope Variables
+ */
+
+/*
+ * Functions
+ */
+
+func TestBindFlags(t *testing.T) {
+	t.Run("flag overrides file", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "int",
+				ValueType:   "int",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+		conf.BindFlags(flagSet)
+		flagParseErr := flagSet.Parse([]string{"--int", "30"})
+		testUtil.NoError(t, flagParseErr)
+
+		parseErr := conf.Parse(ONE_INT_JSON)
+		testUtil.NoError(t, parseErr)
+
+		actual, getErr := conf.GetInt("int")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, 30, actual)
+	})
+
+	t.Run("flag overrides environment variable", func(t *testing.T) {
+		os.Setenv("APP_OBJECT_STRING", "value from env")
+		defer os.Unsetenv("APP_OBJECT_STRING")
+
+		var conf Config
+		addOptionErr := conf.AddOptions([]configOption.Option{
+			{
+				Key:         "object",
+				ValueType:   "object",
+				Description: "some description.",
+				Required:    true,
+			},
+			{
+				Key:         "object.string",
+				ValueType:   "string",
+				Description: "some description.",
+			},
+		})
+		testUtil.NoError(t, addOptionErr)
+
+		conf.BindEnv("APP")
+		flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+		conf.BindFlags(flagSet)
+		flagParseErr := flagSet.Parse([]string{"--object.string=value from flag"})
+		testUtil.NoError(t, flagParseErr)
+
+		parseErr := conf.ParseKeyValues(map[string]interface{}{})
+		testUtil.NoError(t, parseErr)
+
+		actual, getErr := conf.GetString("object.string")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, "value from flag", actual)
+	})
+
+	t.Run("file is used without flag", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "int",
+				ValueType:   "int",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+		conf.BindFlags(flagSet)
+		flagParseErr := flagSet.Parse([]string{})
+		testUtil.NoError(t, flagParseErr)
+
+		parseErr := conf.Parse(ONE_INT_JSON)
+		testUtil.NoError(t, parseErr)
+
+		actual, getErr := conf.GetInt("int")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, 10, actual)
+	})
+
+	t.Run("bool flag without value", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:          "bool",
+				ValueType:    "bool",
+				Description:  "some description.",
+				DefaultValue: false,
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+		conf.BindFlags(flagSet)
+		flagParseErr := flagSet.Parse([]string{"--bool"})
+		testUtil.NoError(t, flagParseErr)
+
+		parseErr := conf.ParseKeyValues(map[string]interface{}{})
+		testUtil.NoError(t, parseErr)
+
+		actual, getErr := conf.GetBool("bool")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, true, actual)
+	})
+
+	t.Run("usage shows description and default value", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:          "int",
+				ValueType:    "int",
+				Description:  "some description.",
+				DefaultValue: 10,
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		var buf bytes.Buffer
+		flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+		flagSet.SetOutput(&buf)
+		conf.BindFlags(flagSet)
+		flagSet.PrintDefaults()
+
+		testUtil.Match(t, true, strings.Contains(buf.String(), "-int"))
+		testUtil.Match(t, true, strings.Contains(buf.String(), "some description."))
+		testUtil.Match(t, true, strings.Contains(buf.String(), "(default 10)"))
+	})
+
+	t.Run("invalid (type is int <-> value is string)", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "int",
+				ValueType:   "int",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+		flagSet.SetOutput(&bytes.Buffer{})
+		conf.BindFlags(flagSet)
+		flagParseErr := flagSet.Parse([]string{"--int", "abc"})
+		testUtil.WithError(t, flagParseErr)
+	})
+}
@@ -0,0 +1,181 @@
+package config
+
+/*
+ * Module Dependencies
+ */
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+/*
+ * Types
+ */
+
+// Position is a location in a config file. Line and Column start at 1, and
+// Column counts characters. File is empty for content without a file name,
+// e.g. ParseBytes.
+type Position struct {
+	File   string
+	Line   int
+	Column int
+}
+
+// location is the position of a key and the snippet rendering it.
+type location struct {
+	pos     Position
+	snippet string
+}
+
+/*
+ * Constants and Package Scope Variables
+ */
+
+/*
+ * Package Private Functions
+ */
+
+// locate returns the location of offset in raw.
+func locate(file string, raw []byte, lineStarts []int, offset int) location {
+	if offset > len(raw) {
+		offset = len(raw)
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	lineIndex := sort.Search(len(lineStarts), func(index int) bool {
+		return lineStarts[index] > offset
+	}) - 1
+	lineStart := lineStarts[lineIndex]
+	lineEnd := len(raw)
+	if newline := bytes.IndexByte(raw[lineStart:], '\n'); newline >= 0 {
+		lineEnd = lineStart + newline
+	}
+	line := strings.TrimRight(string(raw[lineStart:lineEnd]), "\r")
+	prefix := string(raw[lineStart:offset])
+
+	// Drop the indentation of the line, and keep tabs in the caret line so
+	// that the caret lines up with the line.
+	indent := len(line) - len(strings.TrimLeft(line, " \t"))
+	if indent > len(prefix) {
+		indent = len(prefix)
+	}
+	caret := strings.Map(func(r rune) rune {
+		if r == '\t' {
+			return '\t'
+		}
+		return ' '
+	}, prefix[indent:]) + "^"
+	return location{
+		pos: Position{
+			File:   file,
+			Line:   lineIndex + 1,
+			Column: utf8.RuneCountInString(prefix) + 1,
+		},
+		snippet: "    " + line[indent:] + "\n    " + caret,
+	}
+}
+
+// lineStartsOf returns the offsets at which lines of raw start.
+func lineStartsOf(raw []byte) []int {
+	lineStarts := []int{0}
+	for offset, b := range raw {
+		if b == '\n' {
+			lineStarts = append(lineStarts, offset+1)
+		}
+	}
+	return lineStarts
+}
+
+// parentPath returns the key path containing path, e.g. "servers[1]" for
+// "servers[1].host" and "servers" for "servers[1]".
+func parentPath(path string) string {
+	sep := strings.LastIndexAny(path, ".[")
+	if sep < 0 {
+		return ""
+	}
+	return path[:sep]
+}
+
+// locateErrors sets the positions of errs whose key, or the nearest key
+// containing it, is in locations.
+func locateErrors(errs ValidationErrors, locations map[string]location) {
+	if len(locations) == 0 {
+		return
+	}
+	for _, err := range errs {
+		if err.Pos != nil {
+			continue
+		}
+		for path := err.Key; path != ""; path = parentPath(path) {
+			if loc, ok := locations[path]; ok {
+				pos := loc.pos
+				err.Pos = &pos
+				err.Snippet = loc.snippet
+				break
+			}
+		}
+	}
+}
+
+// decode decodes raw with the decoder registered as format. If the decoder
+// is a PositionDecoder, the locations of keys in the file name are returned
+// too, and syntax errors are located.
+func decode(name string, raw []byte, format string) (map[string]interface{}, map[string]location, error) {
+	decoder, err := findDecoder(format)
+	if err != nil {
+		return nil, nil, err
+	}
+	positionDecoder, ok := decoder.(PositionDecoder)
+	if !ok {
+		keyValues, err := decoder.Decode(raw)
+		return keyValues, nil, err
+	}
+	lineStarts := lineStartsOf(raw)
+	keyValues, offsets, err := positionDecoder.DecodeWithOffsets(raw)
+	if err != nil {
+		if syntaxErr, ok := err.(*SyntaxError); ok {
+			loc := locate(name, raw, lineStarts, syntaxErr.Offset)
+			return nil, nil, ValidationErrors{{
+				Err:     err,
+				Pos:     &loc.pos,
+				Snippet: loc.snippet,
+			}}
+		}
+		return nil, nil, err
+	}
+	locations := make(map[string]location, len(offsets))
+	for path, offset := range offsets {
+		locations[path] = locate(name, raw, lineStarts, offset)
+	}
+	return keyValues, locations, nil
+}
+
+// setLocations records where the keys read from a file are, so that
+// Validate can locate its failures.
+func (conf *Config) setLocations(locations map[string]location) {
+	if len(locations) == 0 {
+		return
+	}
+	if conf.locations == nil {
+		conf.locations = make(map[string]location)
+	}
+	for path, loc := range locations {
+		conf.locations[path] = loc
+	}
+}
+
+/*
+ * Public Functions
+ */
+
+func (pos Position) String() string {
+	if pos.File == "" {
+		return fmt.Sprintf("%v:%v", pos.Line, pos.Column)
+	}
+	return fmt.Sprintf("%v:%v:%v", pos.File, pos.Line, pos.Column)
+}
@@ -0,0 +1,185 @@
+package config
+
+/*
+ * Module Dependencies
+ */
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/mozzzzy/config/json/configOption"
+	"github.com/mozzzzy/config/validator"
+	"github.com/mozzzzy/testUtil"
+)
+
+/*
+ * Types
+ */
+
+/*
+ * Constants and Package Scope Variables
+ */
+
+/*
+ * Functions
+ */
+
+// firstValidationError returns the first failure of err, or nil.
+func firstValidationError(err error) *ValidationError {
+	var validationErrs ValidationErrors
+	if !errors.As(err, &validationErrs) || len(validationErrs) == 0 {
+		return nil
+	}
+	return validationErrs[0]
+}
+
+func TestPosition(t *testing.T) {
+	serverOpts := []configOption.Option{
+		{
+			Key:         "servers",
+			ValueType:   "array<object>",
+			Description: "some description.",
+		},
+		{
+			Key:         "servers.host",
+			ValueType:   "string",
+			Description: "some description.",
+		},
+		{
+			Key:         "servers.port",
+			ValueType:   "string",
+			Description: "some description.",
+		},
+		{
+			Key:         "servers.weight",
+			ValueType:   "int",
+			Description: "some description.",
+		},
+	}
+
+	t.Run("type mismatch", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions(serverOpts)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(SERVERS_JSON)
+		testUtil.WithError(t, parseErr)
+		if validationErr := firstValidationError(parseErr); validationErr != nil {
+			testUtil.Match(t, "servers[0].port", validationErr.Key)
+			testUtil.Match(t, &Position{File: SERVERS_JSON, Line: 3, Column: 35}, validationErr.Pos)
+			expect := "testData/servers.json:3:35: servers[0].port: " +
+				"Value of option \"servers[0].port\" is not string. Its type is number.\n" +
+				"    {\"host\": \"alpha.example.com\", \"port\": 8080, \"weight\": 2},\n" +
+				"                                  ^"
+			testUtil.Match(t, expect, validationErr.Error())
+		}
+	})
+
+	t.Run("validator failure", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:            "int",
+				ValueType:      "int",
+				Description:    "some description.",
+				Validator:      validator.IntBiggerThan,
+				ValidatorParam: 100,
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(ONE_INT_JSON)
+		testUtil.WithError(t, parseErr)
+		if validationErr := firstValidationError(parseErr); validationErr != nil {
+			testUtil.Match(t, &Position{File: ONE_INT_JSON, Line: 2, Column: 3}, validationErr.Pos)
+			testUtil.Match(t, "    \"int\": 10\n    ^", validationErr.Snippet)
+		}
+	})
+
+	t.Run("missing required option of element", func(t *testing.T) {
+		opts := append([]configOption.Option{}, serverOpts...)
+		opts[2].ValueType = "int"
+		opts = append(opts, configOption.Option{
+			Key:         "servers.name",
+			ValueType:   "string",
+			Description: "some description.",
+			Required:    true,
+		})
+		var conf Config
+		addOptionErr := conf.AddOptions(opts)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(SERVERS_JSON)
+		testUtil.WithError(t, parseErr)
+		if validationErr := firstValidationError(parseErr); validationErr != nil {
+			testUtil.Match(t, "servers[0].name", validationErr.Key)
+			testUtil.Match(t, &Position{File: SERVERS_JSON, Line: 3, Column: 5}, validationErr.Pos)
+		}
+	})
+
+	t.Run("syntax error", func(t *testing.T) {
+		var conf Config
+		parseErr := conf.ParseBytes([]byte("{\n  \"int\": 10,\n}"), "json")
+		testUtil.WithError(t, parseErr)
+		if validationErr := firstValidationError(parseErr); validationErr != nil {
+			testUtil.Match(t, &Position{Line: 3, Column: 1}, validationErr.Pos)
+			var syntaxErr *SyntaxError
+			testUtil.Match(t, true, errors.As(parseErr, &syntaxErr))
+		}
+	})
+
+	t.Run("value from environment variable is not located", func(t *testing.T) {
+		os.Setenv("APP_INT", "5")
+		defer os.Unsetenv("APP_INT")
+
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:            "int",
+				ValueType:      "int",
+				Description:    "some description.",
+				Validator:      validator.IntBiggerThan,
+				ValidatorParam: 8,
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		conf.BindEnv("APP")
+		parseErr := conf.Parse(ONE_INT_JSON)
+		testUtil.WithError(t, parseErr)
+		if validationErr := firstValidationError(parseErr); validationErr != nil {
+			testUtil.Match(t, (*Position)(nil), validationErr.Pos)
+		}
+	})
+}
+
+func TestDecodeWithOffsets(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		raw := []byte(`{"a": {"b": 1}, "c": [{"d": true}, 2]}`)
+		keyValues, offsets, decodeErr := jsonDecoder{}.DecodeWithOffsets(raw)
+		testUtil.NoError(t, decodeErr)
+		testUtil.Match(t, map[string]int{
+			"a":      1,
+			"a.b":    7,
+			"c":      16,
+			"c[0]":   22,
+			"c[0].d": 23,
+			"c[1]":   35,
+		}, offsets)
+		keyValuesOfDecode, decodeErr := jsonDecoder{}.Decode(raw)
+		testUtil.NoError(t, decodeErr)
+		testUtil.Match(t, keyValuesOfDecode, keyValues)
+	})
+
+	t.Run("invalid (top-level value is not an object)", func(t *testing.T) {
+		_, _, decodeErr := jsonDecoder{}.DecodeWithOffsets([]byte(`[1, 2]`))
+		testUtil.WithError(t, decodeErr)
+	})
+
+	t.Run("invalid (trailing data)", func(t *testing.T) {
+		_, _, decodeErr := jsonDecoder{}.DecodeWithOffsets([]byte(`{} {}`))
+		testUtil.WithError(t, decodeErr)
+	})
+}
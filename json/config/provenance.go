@@ -0,0 +1,168 @@
+package config
+
+/*
+ * Module Dependencies
+ */
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mozzzzy/config/json/configOption"
+)
+
+/*
+ * Types
+ */
+
+/*
+ * Constants and Package Scope Variables
+ */
+
+/*
+ * Package Private Functions
+ */
+
+// resolveOrigin returns the option recording the origin of the value at
+// key, and the value. Elements of arrays and values of maps come from their
+// array or map option, and keys in elements of arrays and maps of objects
+// are resolved by the options of the element, e.g. "servers[0].host".
+func (conf Config) resolveOrigin(key string) (*configOption.Option, interface{}, error) {
+	if opt := conf.findOptByKey(key); opt != nil {
+		value, _ := opt.GetValue()
+		return opt, value, nil
+	}
+	for sep := 0; sep < len(key); sep++ {
+		if key[sep] != '.' && key[sep] != '[' {
+			continue
+		}
+		opt := conf.findOptByKey(key[:sep])
+		if opt == nil {
+			continue
+		}
+		_, isArray := configOption.ElementType(opt.ValueType)
+		_, isMap := configOption.MapValueType(opt.ValueType)
+		if !isArray && !isMap && opt.ValueType != "array" && opt.ValueType != "map" {
+			continue
+		}
+		if opt.ValueType != "array<object>" && opt.ValueType != "map<object>" {
+			value, err := conf.Get(key)
+			if err != nil {
+				return nil, nil, err
+			}
+			return opt, value, nil
+		}
+		// The element is e.g. "servers[0]" of "servers[0].host", or
+		// "plugins.auth" of "plugins.auth.enabled".
+		end := len(key)
+		if next := strings.IndexAny(key[sep+1:], ".["); next >= 0 {
+			end = sep + 1 + next
+		}
+		value, err := conf.Get(key[:end])
+		if err != nil {
+			return nil, nil, err
+		}
+		if end == len(key) {
+			return opt, value, nil
+		}
+		elemConf, ok := value.(Config)
+		if !ok || key[end] != '.' {
+			break
+		}
+		return elemConf.resolveOrigin(key[end+1:])
+	}
+	return nil, nil, fmt.Errorf("%w: %v", ErrKeyNotFound, key)
+}
+
+/*
+ * Public Functions
+ */
+
+// Explain describes where the value of the option of key came from, and
+// which values it overrode, e.g.
+//
+//	object.int = 7
+//	  from flag -object.int
+//	  overrides environment variable APP_OBJECT_INT
+//	  overrides file config.json:4:12
+//	  overrides default value 3
+//
+// key may also be an element of an array or a value of a map, e.g.
+// "servers[0].host" or "limits.tenant-a". Such values are explained by the
+// origin of the element or the map.
+func (conf Config) Explain(key string) (string, error) {
+	opt, value, err := conf.resolveOrigin(key)
+	if err != nil {
+		return "", err
+	}
+	if !opt.IsSet() {
+		if value == nil {
+			return fmt.Sprintf("%v is not set\n", key), nil
+		}
+		return fmt.Sprintf("%v = %v\n  from default value\n", key, value), nil
+	}
+	str := fmt.Sprintf("%v = %v\n  from %v\n", key, value, opt.Origin)
+	for index := len(opt.Overridden) - 1; index >= 0; index-- {
+		str += fmt.Sprintf("  overrides %v\n", opt.Overridden[index])
+	}
+	if opt.DefaultValue != nil {
+		str += fmt.Sprintf("  overrides default value %v\n", opt.DefaultValue)
+	}
+	return str, nil
+}
+
+// Set sets the option of key to value, overriding its current value.
+// value is either of the type returned by the getter of the option, e.g.
+// time.Duration, or converted as values decoded from a file are, e.g. "1s"
+// for a duration. Set doesn't run validators; call Validate after it.
+func (conf *Config) Set(key string, value interface{}) error {
+	opt := conf.findOptByKey(key)
+	if opt == nil {
+		opt = conf.instantiate(key)
+	}
+	if opt == nil {
+		return fmt.Errorf("%w: %v", ErrKeyNotFound, key)
+	}
+	if opt.ValueType == "nil" || opt.ValueType == "object" ||
+		opt.ValueType == "array<object>" || opt.ValueType == "map<object>" {
+		return errors.New(fmt.Sprintf("Option %v of type %v can't be set.", key, opt.ValueType))
+	}
+	// Values in the shapes decoders produce are converted, and others, e.g.
+	// time.Duration or *url.URL, are set as they are.
+	converted := value
+	switch value.(type) {
+	case bool, float64, int, int64, json.Number, string, uint64,
+		[]interface{}, map[string]interface{}:
+		var err error
+		converted, err = convertValue(key, opt.ValueType, value)
+		if err != nil {
+			return err
+		}
+	}
+	origin := configOption.Origin{Kind: configOption.ORIGIN_SET}
+	if err := opt.SetValueFrom(converted, origin); err != nil {
+		return err
+	}
+	delete(conf.locations, key)
+	return conf.setParents(key, origin)
+}
+
+// Sources returns where the value of every option came from, keyed by
+// option keys. Options using their default value have an Origin of
+// ORIGIN_DEFAULT, and options without a value are omitted.
+func (conf Config) Sources() map[string]configOption.Origin {
+	sources := make(map[string]configOption.Origin)
+	for _, opt := range conf.options {
+		if conf.isElemOption(opt.Key) || isPattern(opt.Key) {
+			continue
+		}
+		if opt.IsSet() {
+			sources[opt.Key] = opt.Origin
+		} else if opt.DefaultValue != nil {
+			sources[opt.Key] = configOption.Origin{Kind: configOption.ORIGIN_DEFAULT}
+		}
+	}
+	return sources
+}
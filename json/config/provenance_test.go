@@ -0,0 +1,307 @@
+package config
+
+/*
+ * Module Dependencies
+ */
+
+import (
+	"errors"
+	"flag"
+	"net/netip"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mozzzzy/config/json/configOption"
+	"github.com/mozzzzy/testUtil"
+)
+
+/*
+ * Types
+ */
+
+/*
+ * Constants and Package Scope Variables
+ */
+
+/*
+ * Functions
+ */
+
+func provenanceOptions() []configOption.Option {
+	return []configOption.Option{
+		{
+			Key:         "int",
+			ValueType:   "int",
+			Description: "some description.",
+		},
+		{
+			Key:         "object",
+			ValueType:   "object",
+			Description: "some description.",
+		},
+		{
+			Key:          "object.int",
+			ValueType:    "int",
+			Description:  "some description.",
+			DefaultValue: 3,
+		},
+		{
+			Key:          "object.string",
+			ValueType:    "string",
+			Description:  "some description.",
+			DefaultValue: "default value",
+		},
+	}
+}
+
+func TestExplain(t *testing.T) {
+	t.Run("overridden values", func(t *testing.T) {
+		os.Setenv("APP_OBJECT_INT", "7")
+		defer os.Unsetenv("APP_OBJECT_INT")
+
+		var conf Config
+		addOptionErr := conf.AddOptions(provenanceOptions())
+		testUtil.NoError(t, addOptionErr)
+
+		conf.BindEnv("APP")
+		flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+		conf.BindFlags(flagSet)
+		flagParseErr := flagSet.Parse([]string{"--object.int", "9"})
+		testUtil.NoError(t, flagParseErr)
+
+		parseErr := conf.Parse(ALL_IN_ONE_JSON)
+		testUtil.NoError(t, parseErr)
+
+		explanation, explainErr := conf.Explain("object.int")
+		testUtil.NoError(t, explainErr)
+		expect := "object.int = 9\n" +
+			"  from flag -object.int\n" +
+			"  overrides environment variable APP_OBJECT_INT\n" +
+			"  overrides file testData/all_in_one.json:9:5\n" +
+			"  overrides default value 3\n"
+		testUtil.Match(t, expect, explanation)
+	})
+
+	t.Run("default value", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions(provenanceOptions())
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.ParseKeyValues(map[string]interface{}{})
+		testUtil.NoError(t, parseErr)
+
+		explanation, explainErr := conf.Explain("object.string")
+		testUtil.NoError(t, explainErr)
+		testUtil.Match(t, "object.string = default value\n  from default value\n", explanation)
+
+		explanation, explainErr = conf.Explain("int")
+		testUtil.NoError(t, explainErr)
+		testUtil.Match(t, "int is not set\n", explanation)
+	})
+
+	t.Run("elements of arrays and maps", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions([]configOption.Option{
+			{
+				Key:         "servers",
+				ValueType:   "array<object>",
+				Description: "some description.",
+			},
+			{
+				Key:         "servers.host",
+				ValueType:   "string",
+				Description: "some description.",
+			},
+			{
+				Key:         "servers.port",
+				ValueType:   "int",
+				Description: "some description.",
+			},
+			{
+				Key:          "servers.weight",
+				ValueType:    "int",
+				Description:  "some description.",
+				DefaultValue: 1,
+			},
+		})
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(SERVERS_JSON)
+		testUtil.NoError(t, parseErr)
+
+		explanation, explainErr := conf.Explain("servers[1].host")
+		testUtil.NoError(t, explainErr)
+		testUtil.Match(t, "servers[1].host = beta.example.com\n"+
+			"  from file testData/servers.json:4:6\n", explanation)
+
+		explanation, explainErr = conf.Explain("servers[1].weight")
+		testUtil.NoError(t, explainErr)
+		testUtil.Match(t, "servers[1].weight = 1\n  from default value\n", explanation)
+
+		_, explainErr = conf.Explain("servers[2].host")
+		testUtil.Match(t, true, errors.Is(explainErr, ErrKeyNotFound))
+
+		var mapConf Config
+		addOptionErr = mapConf.AddOptions([]configOption.Option{
+			{
+				Key:         "limits",
+				ValueType:   "map<int>",
+				Description: "some description.",
+			},
+			{
+				Key:         "plugins",
+				ValueType:   "map<object>",
+				Description: "some description.",
+			},
+			{
+				Key:         "plugins.enabled",
+				ValueType:   "bool",
+				Description: "some description.",
+			},
+			{
+				Key:         "plugins.ttl",
+				ValueType:   "duration",
+				Description: "some description.",
+			},
+		})
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr = mapConf.ParseBytes([]byte(`{
+  "limits": {"a": 100},
+  "plugins": {"auth": {"enabled": true}}
+}`), "json")
+		testUtil.NoError(t, parseErr)
+
+		explanation, explainErr = mapConf.Explain("limits.a")
+		testUtil.NoError(t, explainErr)
+		testUtil.Match(t, "limits.a = 100\n  from file <bytes>:2:3\n", explanation)
+
+		explanation, explainErr = mapConf.Explain("plugins.auth.enabled")
+		testUtil.NoError(t, explainErr)
+		testUtil.Match(t, "plugins.auth.enabled = true\n  from file <bytes>:3:24\n", explanation)
+	})
+
+	t.Run("invalid (key is not found)", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions(provenanceOptions())
+		testUtil.NoError(t, addOptionErr)
+
+		_, explainErr := conf.Explain("not.found")
+		testUtil.Match(t, true, errors.Is(explainErr, ErrKeyNotFound))
+	})
+}
+
+func TestSet(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions(provenanceOptions())
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(ALL_IN_ONE_JSON)
+		testUtil.NoError(t, parseErr)
+
+		setErr := conf.Set("int", 30)
+		testUtil.NoError(t, setErr)
+
+		actual, getErr := conf.GetInt("int")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, 30, actual)
+		testUtil.Match(t, configOption.Origin{Kind: configOption.ORIGIN_SET}, conf.Sources()["int"])
+	})
+
+	t.Run("invalid (type is int <-> value is string)", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions(provenanceOptions())
+		testUtil.NoError(t, addOptionErr)
+
+		setErr := conf.Set("int", "abc")
+		testUtil.WithError(t, setErr)
+	})
+
+	t.Run("native values", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions([]configOption.Option{
+			{Key: "cidr", ValueType: "cidr", Description: "some description."},
+			{Key: "duration", ValueType: "duration", Description: "some description."},
+			{Key: "hostport", ValueType: "hostport", Description: "some description."},
+			{Key: "ip", ValueType: "ip", Description: "some description."},
+			{Key: "time", ValueType: "time", Description: "some description."},
+			{Key: "url", ValueType: "url", Description: "some description."},
+		})
+		testUtil.NoError(t, addOptionErr)
+
+		prefix := netip.MustParsePrefix("10.0.0.0/8")
+		addrPort := netip.MustParseAddrPort("127.0.0.1:8080")
+		addr := netip.MustParseAddr("::1")
+		tm := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+		urlPtr, _ := url.Parse("https://example.com/path")
+		for key, value := range map[string]interface{}{
+			"cidr":     prefix,
+			"duration": 5 * time.Second,
+			"hostport": addrPort,
+			"ip":       addr,
+			"time":     tm,
+			"url":      urlPtr,
+		} {
+			setErr := conf.Set(key, value)
+			testUtil.NoError(t, setErr)
+			actual, getErr := conf.Get(key)
+			testUtil.NoError(t, getErr)
+			testUtil.Match(t, value, actual)
+		}
+
+		setErr := conf.Set("duration", "1m")
+		testUtil.NoError(t, setErr)
+		duration, getErr := conf.GetDuration("duration")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, time.Minute, duration)
+	})
+
+	t.Run("invalid (native value of another type)", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "duration",
+				ValueType:   "duration",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		setErr := conf.Set("duration", time.Now())
+		var typeMismatchErr *TypeMismatchError
+		testUtil.Match(t, true, errors.As(setErr, &typeMismatchErr))
+	})
+
+	t.Run("invalid (option is an object)", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions(provenanceOptions())
+		testUtil.NoError(t, addOptionErr)
+
+		setErr := conf.Set("object", 0)
+		testUtil.WithError(t, setErr)
+	})
+}
+
+func TestSources(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions(provenanceOptions())
+		testUtil.NoError(t, addOptionErr)
+
+		loadErr := conf.Load(
+			KeyValuesSource(map[string]interface{}{"int": 1}),
+			BytesSource([]byte(`{"object": {"int": 5}}`), "json"),
+		)
+		testUtil.NoError(t, loadErr)
+
+		testUtil.Match(t, map[string]configOption.Origin{
+			"int":           {Kind: configOption.ORIGIN_KEY_VALUES},
+			"object":        {Kind: configOption.ORIGIN_FILE, Line: 1, Column: 2},
+			"object.int":    {Kind: configOption.ORIGIN_FILE, Line: 1, Column: 13},
+			"object.string": {Kind: configOption.ORIGIN_DEFAULT},
+		}, conf.Sources())
+	})
+}
@@ -0,0 +1,324 @@
+package config
+
+/*
+ * Module Dependencies
+ */
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mozzzzy/config/json/configOption"
+	"github.com/mozzzzy/config/validator"
+)
+
+/*
+ * Types
+ */
+
+// ValidatorParamParser parses the `validatorParam` tag of a struct field
+// into the ValidatorParam passed to a validator.
+type ValidatorParamParser func(param string) (interface{}, error)
+
+type namedValidator struct {
+	validator  func(interface{}, interface{}) error
+	parseParam ValidatorParamParser
+}
+
+/*
+ * Constants and Package Scope Variables
+ */
+
+var (
+	validatorsMutex sync.RWMutex
+	validators      = make(map[string]namedValidator)
+)
+
+/*
+ * Package Private Functions
+ */
+
+func init() {
+	RegisterValidator("ByteSizeBiggerThan", validator.ByteSizeBiggerThan, parseByteSizeParam)
+	RegisterValidator("ByteSizeSmallerThan", validator.ByteSizeSmallerThan, parseByteSizeParam)
+	RegisterValidator("ByteSizeWithin", validator.ByteSizeWithin, parseByteSizeArrayParam)
+	RegisterValidator("DurationBiggerThan", validator.DurationBiggerThan, parseDurationParam)
+	RegisterValidator("DurationSmallerThan", validator.DurationSmallerThan, parseDurationParam)
+	RegisterValidator("DurationWithin", validator.DurationWithin, parseDurationArrayParam)
+	RegisterValidator("IntBiggerThan", validator.IntBiggerThan, parseIntParam)
+	RegisterValidator("IntSmallerThan", validator.IntSmallerThan, parseIntParam)
+	RegisterValidator("IntWithin", validator.IntWithin, parseIntArrayParam)
+	RegisterValidator("TimeAfter", validator.TimeAfter, parseTimeParam)
+	RegisterValidator("TimeBefore", validator.TimeBefore, parseTimeParam)
+	RegisterValidator("TimeWithin", validator.TimeWithin, parseTimeArrayParam)
+}
+
+func parseByteSizeParam(param string) (interface{}, error) {
+	return parseByteSize(param)
+}
+
+func parseByteSizeArrayParam(param string) (interface{}, error) {
+	var bytesArray []int64
+	for _, elem := range strings.Split(param, ",") {
+		bytes, err := parseByteSize(elem)
+		if err != nil {
+			return nil, err
+		}
+		bytesArray = append(bytesArray, bytes)
+	}
+	return bytesArray, nil
+}
+
+func parseDurationParam(param string) (interface{}, error) {
+	return time.ParseDuration(strings.TrimSpace(param))
+}
+
+func parseDurationArrayParam(param string) (interface{}, error) {
+	var durationArray []time.Duration
+	for _, elem := range strings.Split(param, ",") {
+		duration, err := time.ParseDuration(strings.TrimSpace(elem))
+		if err != nil {
+			return nil, err
+		}
+		durationArray = append(durationArray, duration)
+	}
+	return durationArray, nil
+}
+
+func parseIntParam(param string) (interface{}, error) {
+	return strconv.Atoi(strings.TrimSpace(param))
+}
+
+func parseIntArrayParam(param string) (interface{}, error) {
+	var intArray []int
+	for _, elem := range strings.Split(param, ",") {
+		integer, err := strconv.Atoi(strings.TrimSpace(elem))
+		if err != nil {
+			return nil, err
+		}
+		intArray = append(intArray, integer)
+	}
+	return intArray, nil
+}
+
+func parseTimeParam(param string) (interface{}, error) {
+	return time.Parse(time.RFC3339, strings.TrimSpace(param))
+}
+
+func parseTimeArrayParam(param string) (interface{}, error) {
+	var timeArray []time.Time
+	for _, elem := range strings.Split(param, ",") {
+		tm, err := time.Parse(time.RFC3339, strings.TrimSpace(elem))
+		if err != nil {
+			return nil, err
+		}
+		timeArray = append(timeArray, tm)
+	}
+	return timeArray, nil
+}
+
+// valueTypeOf returns the ValueType of options stored in fields of t.
+func valueTypeOf(t reflect.Type) (string, error) {
+	switch t {
+	case reflect.TypeOf(netip.Prefix{}):
+		return "cidr", nil
+	case reflect.TypeOf(time.Duration(0)):
+		return "duration", nil
+	case reflect.TypeOf(netip.AddrPort{}):
+		return "hostport", nil
+	case reflect.TypeOf(netip.Addr{}):
+		return "ip", nil
+	case reflect.TypeOf(time.Time{}):
+		return "time", nil
+	case reflect.TypeOf(&url.URL{}):
+		return "url", nil
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "bool", nil
+	case reflect.Float32, reflect.Float64:
+		return "float64", nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return "int", nil
+	case reflect.Int64, reflect.Uint64:
+		return "int64", nil
+	case reflect.Slice:
+		elemType, err := valueTypeOf(t.Elem())
+		if err != nil || strings.HasPrefix(elemType, "array") {
+			return "array", nil
+		}
+		return "array<" + elemType + ">", nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			break
+		}
+		valueType, err := valueTypeOf(t.Elem())
+		if err != nil || strings.HasPrefix(valueType, "array") || strings.HasPrefix(valueType, "map") {
+			return "map", nil
+		}
+		return "map<" + valueType + ">", nil
+	case reflect.String:
+		return "string", nil
+	case reflect.Struct:
+		return "object", nil
+	}
+	return "", errors.New(fmt.Sprintf("Type %v is not supported.", t))
+}
+
+func optionsOf(structType reflect.Type, parentKey string, opts *[]configOption.Option) error {
+	for index := 0; index < structType.NumField(); index++ {
+		fieldType := structType.Field(index)
+		tag := fieldType.Tag.Get(TAG_NAME)
+		if tag == "" || tag == "-" || fieldType.PkgPath != "" {
+			continue
+		}
+		opt := configOption.Option{
+			Key:         joinKey(parentKey, tag),
+			Description: fieldType.Tag.Get("description"),
+			ValueType:   fieldType.Tag.Get("type"),
+		}
+		if opt.ValueType == "" {
+			valueType, err := valueTypeOf(fieldType.Type)
+			if err != nil {
+				return errors.New(fmt.Sprintf("Invalid field %v for option \"%v\". %v", fieldType.Name, opt.Key, err))
+			}
+			opt.ValueType = valueType
+		}
+		if required, ok := fieldType.Tag.Lookup("required"); ok {
+			isRequired, err := strconv.ParseBool(required)
+			if err != nil {
+				return errors.New(fmt.Sprintf("Invalid required tag \"%v\" of field %v.", required, fieldType.Name))
+			}
+			opt.Required = isRequired
+		}
+		if defaultValue, ok := fieldType.Tag.Lookup("default"); ok {
+			value, err := parseString(&opt, defaultValue)
+			if err != nil {
+				return errors.New(fmt.Sprintf("Invalid default tag of field %v. %v", fieldType.Name, err))
+			}
+			opt.DefaultValue = value
+		}
+		for tagName, length := range map[string]*int{"minLen": &opt.MinLen, "maxLen": &opt.MaxLen} {
+			if str, ok := fieldType.Tag.Lookup(tagName); ok {
+				integer, err := strconv.Atoi(str)
+				if err != nil {
+					return errors.New(fmt.Sprintf("Invalid %v tag \"%v\" of field %v.", tagName, str, fieldType.Name))
+				}
+				*length = integer
+			}
+		}
+		if allowed, ok := fieldType.Tag.Lookup("allowed"); ok {
+			// Allowed values of typed arrays and maps are element values.
+			allowedOpt := opt
+			if elemType, ok := configOption.ElementType(opt.ValueType); ok {
+				allowedOpt.ValueType = elemType
+			}
+			if mapValueType, ok := configOption.MapValueType(opt.ValueType); ok {
+				allowedOpt.ValueType = mapValueType
+			}
+			for _, elem := range strings.Split(allowed, ",") {
+				value, err := parseString(&allowedOpt, strings.TrimSpace(elem))
+				if err != nil {
+					return errors.New(fmt.Sprintf("Invalid allowed tag of field %v. %v", fieldType.Name, err))
+				}
+				opt.AllowedValues = append(opt.AllowedValues, value)
+			}
+		}
+		if name, ok := fieldType.Tag.Lookup("validator"); ok {
+			validatorsMutex.RLock()
+			named, found := validators[name]
+			validatorsMutex.RUnlock()
+			if !found {
+				return errors.New(fmt.Sprintf("Validator \"%v\" of field %v is not registered.", name, fieldType.Name))
+			}
+			opt.Validator = named.validator
+			if param, ok := fieldType.Tag.Lookup("validatorParam"); ok && named.parseParam != nil {
+				validatorParam, err := named.parseParam(param)
+				if err != nil {
+					return errors.New(fmt.Sprintf("Invalid validatorParam tag of field %v. %v", fieldType.Name, err))
+				}
+				opt.ValidatorParam = validatorParam
+			}
+		}
+		*opts = append(*opts, opt)
+
+		if opt.ValueType == "object" && fieldType.Type.Kind() == reflect.Struct {
+			if err := optionsOf(fieldType.Type, opt.Key, opts); err != nil {
+				return err
+			}
+		}
+		if opt.ValueType == "array<object>" || opt.ValueType == "map<object>" {
+			kind := reflect.Map
+			if opt.ValueType == "array<object>" {
+				kind = reflect.Slice
+			}
+			if fieldType.Type.Kind() != kind {
+				return errors.New(fmt.Sprintf(
+					"Invalid field %v for option \"%v\". Type %v can't be %v.",
+					fieldType.Name, opt.Key, fieldType.Type, opt.ValueType))
+			}
+			if fieldType.Type.Elem().Kind() == reflect.Struct {
+				if err := optionsOf(fieldType.Type.Elem(), opt.Key, opts); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+/*
+ * Public Functions
+ */
+
+// OptionsOf derives options from the tagged fields of the struct v (or a
+// pointer to it), so that the same struct can be used with AddOptions and
+// Unmarshal. Following tags are read.
+//
+//	config:"key"               key of the option, relative to the parent struct
+//	description:"..."          Description
+//	type:"int64"               ValueType, derived from the field type if omitted
+//	default:"10"               DefaultValue, parsed according to ValueType
+//	required:"true"            Required
+//	allowed:"debug,info,warn"  AllowedValues, parsed according to ValueType
+//	minLen:"1" maxLen:"10"     MinLen and MaxLen of arrays
+//	validator:"IntWithin"      Validator registered by RegisterValidator
+//	validatorParam:"1,10"      ValidatorParam, parsed by the validator's parser
+func OptionsOf(v interface{}) ([]configOption.Option, error) {
+	structType := reflect.TypeOf(v)
+	if structType != nil && structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType == nil || structType.Kind() != reflect.Struct {
+		return nil, errors.New(fmt.Sprintf("OptionsOf requires a struct. But specified value is %T.", v))
+	}
+	var opts []configOption.Option
+	if err := optionsOf(structType, "", &opts); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
+// RegisterValidator makes validator available to the `validator` tag read
+// by OptionsOf. parseParam parses the `validatorParam` tag and may be nil
+// for validators without parameters.
+func RegisterValidator(
+	name string,
+	validator func(interface{}, interface{}) error,
+	parseParam ValidatorParamParser,
+) {
+	validatorsMutex.Lock()
+	defer validatorsMutex.Unlock()
+	validators[name] = namedValidator{
+		validator:  validator,
+		parseParam: parseParam,
+	}
+}
@@ -0,0 +1,280 @@
+package config
+
+/*
+ * Module Dependencies
+ */
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mozzzzy/testUtil"
+)
+
+/*
+ * Types
+ */
+
+type schemaSettings struct {
+	Array   []string `config:"array" description:"some array."`
+	Float64 float64  `config:"float64" description:"some float64." default:"0.5"`
+	Int     int      `config:"int" description:"some int." required:"true" validator:"IntWithin" validatorParam:"1, 10"`
+	Int64   int64    `config:"int64" description:"some int64." default:"2147483648"`
+	String  string   `config:"string" description:"some string."`
+	Object  struct {
+		Int    int    `config:"int" description:"some int." validator:"IntBiggerThan" validatorParam:"10"`
+		String string `config:"string" description:"some string." default:"default value"`
+	} `config:"object" description:"some object." required:"true"`
+	Ignored string
+}
+
+/*
+ * Constants and Package Scope Variables
+ */
+
+/*
+ * Functions
+ */
+
+func TestOptionsOf(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		opts, optionsOfErr := OptionsOf(&schemaSettings{})
+		testUtil.NoError(t, optionsOfErr)
+
+		var conf Config
+		addOptionErr := conf.AddOptions(opts)
+		testUtil.NoError(t, addOptionErr)
+		testUtil.Match(t, []string{
+			"array",
+			"float64",
+			"int",
+			"int64",
+			"object",
+			"object.int",
+			"object.string",
+			"string",
+		}, conf.GetAllKeys())
+
+		parseErr := conf.Parse(ALL_IN_ONE_JSON)
+		testUtil.NoError(t, parseErr)
+
+		var settings schemaSettings
+		unmarshalErr := conf.Unmarshal(&settings)
+		testUtil.NoError(t, unmarshalErr)
+		testUtil.Match(t, []string{"some", "value"}, settings.Array)
+		testUtil.Match(t, 1.2, settings.Float64)
+		testUtil.Match(t, 10, settings.Int)
+		testUtil.Match(t, int64(2147483648), settings.Int64)
+		testUtil.Match(t, 20, settings.Object.Int)
+		testUtil.Match(t, "some value in object", settings.Object.String)
+	})
+
+	t.Run("invalid (validation error)", func(t *testing.T) {
+		opts, optionsOfErr := OptionsOf(schemaSettings{})
+		testUtil.NoError(t, optionsOfErr)
+
+		var conf Config
+		addOptionErr := conf.AddOptions(opts)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.ParseBytes([]byte(`{"int": 11, "object": {}}`), "json")
+		testUtil.WithError(t, parseErr)
+	})
+
+	t.Run("valid units", func(t *testing.T) {
+		var settings struct {
+			ByteSize int64         `config:"bytesize" type:"bytesize" validator:"ByteSizeWithin" validatorParam:"1MiB, 1GiB"`
+			Duration time.Duration `config:"duration" validator:"DurationBiggerThan" validatorParam:"1s"`
+			Timeout  time.Duration `config:"timeout" default:"5s"`
+			Time     time.Time     `config:"time" validator:"TimeAfter" validatorParam:"2020-01-01T00:00:00Z"`
+		}
+		opts, optionsOfErr := OptionsOf(settings)
+		testUtil.NoError(t, optionsOfErr)
+
+		var conf Config
+		addOptionErr := conf.AddOptions(opts)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(UNITS_JSON)
+		testUtil.NoError(t, parseErr)
+
+		unmarshalErr := conf.Unmarshal(&settings)
+		testUtil.NoError(t, unmarshalErr)
+		testUtil.Match(t, int64(512*1024*1024), settings.ByteSize)
+		testUtil.Match(t, 90*time.Second, settings.Duration)
+		testUtil.Match(t, 5*time.Second, settings.Timeout)
+		testUtil.Match(t, 2020, settings.Time.Year())
+	})
+
+	t.Run("default value with validator", func(t *testing.T) {
+		var settings struct {
+			Timeout time.Duration `config:"timeout" default:"5s" validator:"DurationWithin" validatorParam:"1s,10s"`
+		}
+		opts, optionsOfErr := OptionsOf(settings)
+		testUtil.NoError(t, optionsOfErr)
+
+		var conf Config
+		addOptionErr := conf.AddOptions(opts)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.ParseBytes([]byte("{}"), "json")
+		testUtil.NoError(t, parseErr)
+
+		unmarshalErr := conf.Unmarshal(&settings)
+		testUtil.NoError(t, unmarshalErr)
+		testUtil.Match(t, 5*time.Second, settings.Timeout)
+	})
+
+	t.Run("allowed values", func(t *testing.T) {
+		opts, optionsOfErr := OptionsOf(struct {
+			Level string `config:"level" allowed:"debug, info, warn" default:"info"`
+		}{})
+		testUtil.NoError(t, optionsOfErr)
+		testUtil.Match(t, []interface{}{"debug", "info", "warn"}, opts[0].AllowedValues)
+
+		var conf Config
+		addOptionErr := conf.AddOptions(opts)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.ParseBytes([]byte(`{"level": "trace"}`), "json")
+		testUtil.WithError(t, parseErr)
+	})
+
+	t.Run("typed arrays", func(t *testing.T) {
+		var settings struct {
+			Durations []time.Duration `config:"durations"`
+			Ports     []int           `config:"ports" minLen:"1" maxLen:"2"`
+		}
+		opts, optionsOfErr := OptionsOf(settings)
+		testUtil.NoError(t, optionsOfErr)
+		testUtil.Match(t, "array<duration>", opts[0].ValueType)
+		testUtil.Match(t, "array<int>", opts[1].ValueType)
+		testUtil.Match(t, 2, opts[1].MaxLen)
+
+		var conf Config
+		addOptionErr := conf.AddOptions(opts)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(TYPED_ARRAY_JSON)
+		testUtil.NoError(t, parseErr)
+
+		unmarshalErr := conf.Unmarshal(&settings)
+		testUtil.NoError(t, unmarshalErr)
+		testUtil.Match(t, []time.Duration{time.Second, 90 * time.Second}, settings.Durations)
+		testUtil.Match(t, []int{80, 443}, settings.Ports)
+	})
+
+	t.Run("array of objects", func(t *testing.T) {
+		var settings struct {
+			Servers []struct {
+				Host   string `config:"host" required:"true"`
+				Port   int    `config:"port"`
+				Weight int    `config:"weight" default:"1"`
+			} `config:"servers"`
+		}
+		opts, optionsOfErr := OptionsOf(settings)
+		testUtil.NoError(t, optionsOfErr)
+		testUtil.Match(t, "array<object>", opts[0].ValueType)
+
+		var conf Config
+		addOptionErr := conf.AddOptions(opts)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(SERVERS_JSON)
+		testUtil.NoError(t, parseErr)
+
+		unmarshalErr := conf.Unmarshal(&settings)
+		testUtil.NoError(t, unmarshalErr)
+		testUtil.Match(t, 2, len(settings.Servers))
+		if len(settings.Servers) == 2 {
+			testUtil.Match(t, "alpha.example.com", settings.Servers[0].Host)
+			testUtil.Match(t, 8081, settings.Servers[1].Port)
+			testUtil.Match(t, 1, settings.Servers[1].Weight)
+		}
+	})
+
+	t.Run("maps", func(t *testing.T) {
+		var settings struct {
+			Limits  map[string]int `config:"limits"`
+			Plugins map[string]struct {
+				Enabled bool `config:"enabled"`
+			} `config:"plugins"`
+		}
+		opts, optionsOfErr := OptionsOf(settings)
+		testUtil.NoError(t, optionsOfErr)
+		testUtil.Match(t, "map<int>", opts[0].ValueType)
+		testUtil.Match(t, "map<object>", opts[1].ValueType)
+
+		var conf Config
+		addOptionErr := conf.AddOptions(opts)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(MAPS_JSON)
+		testUtil.NoError(t, parseErr)
+
+		unmarshalErr := conf.Unmarshal(&settings)
+		testUtil.NoError(t, unmarshalErr)
+		testUtil.Match(t, map[string]int{"tenant-a": 100, "tenant-b": 200}, settings.Limits)
+		testUtil.Match(t, true, settings.Plugins["auth"].Enabled)
+		testUtil.Match(t, false, settings.Plugins["cache"].Enabled)
+	})
+
+	t.Run("invalid (unsupported type)", func(t *testing.T) {
+		_, optionsOfErr := OptionsOf(struct {
+			Map map[int]string `config:"map"`
+		}{})
+		testUtil.WithError(t, optionsOfErr)
+	})
+
+	t.Run("invalid (array<object> type of a struct field)", func(t *testing.T) {
+		_, optionsOfErr := OptionsOf(struct {
+			Server struct {
+				Host string `config:"host"`
+			} `config:"server" type:"array<object>"`
+		}{})
+		testUtil.WithError(t, optionsOfErr)
+	})
+
+	t.Run("invalid (default value is not int)", func(t *testing.T) {
+		_, optionsOfErr := OptionsOf(struct {
+			Int int `config:"int" default:"abc"`
+		}{})
+		testUtil.WithError(t, optionsOfErr)
+	})
+
+	t.Run("invalid (validator is not registered)", func(t *testing.T) {
+		_, optionsOfErr := OptionsOf(struct {
+			Int int `config:"int" validator:"NotRegistered"`
+		}{})
+		testUtil.WithError(t, optionsOfErr)
+	})
+
+	t.Run("invalid (not a struct)", func(t *testing.T) {
+		_, optionsOfErr := OptionsOf(10)
+		testUtil.WithError(t, optionsOfErr)
+	})
+}
+
+func TestRegisterValidator(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		RegisterValidator("NotEmpty", func(value interface{}, param interface{}) error {
+			if str, _ := value.(string); str == "" {
+				return errors.New("empty string")
+			}
+			return nil
+		}, nil)
+
+		opts, optionsOfErr := OptionsOf(struct {
+			String string `config:"string" validator:"NotEmpty"`
+		}{})
+		testUtil.NoError(t, optionsOfErr)
+
+		var conf Config
+		addOptionErr := conf.AddOptions(opts)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.ParseBytes([]byte(`{"string": ""}`), "json")
+		testUtil.WithError(t, parseErr)
+	})
+}
@@ -0,0 +1,166 @@
+package config
+
+/*
+ * Module Dependencies
+ */
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/mozzzzy/config/json/configOption"
+)
+
+/*
+ * Types
+ */
+
+// Source is one layer of values loaded by Config.Load.
+// Apply sets options of conf, overriding values set by earlier layers.
+type Source interface {
+	Apply(conf *Config) error
+}
+
+// SourceFunc adapts an ordinary function to the Source interface.
+type SourceFunc func(conf *Config) error
+
+/*
+ * Constants and Package Scope Variables
+ */
+
+/*
+ * Package Private Functions
+ */
+
+func fileSource(path string, optional bool) Source {
+	return SourceFunc(func(conf *Config) error {
+		raw, readFileErr := ioutil.ReadFile(path)
+		if readFileErr != nil {
+			if optional && os.IsNotExist(readFileErr) {
+				return nil
+			}
+			return readFileErr
+		}
+		return bytesSource(path, raw, formatOf(path)).Apply(conf)
+	})
+}
+
+// bytesSource is BytesSource reporting name as the file of failures.
+func bytesSource(name string, raw []byte, format string) Source {
+	return SourceFunc(func(conf *Config) error {
+		keyValues, locations, decodeErr := decode(name, raw, format)
+		if decodeErr != nil {
+			return decodeErr
+		}
+		conf.setLocations(locations)
+		return applyLayer(conf, keyValues, layer{
+			origin:    configOption.Origin{Kind: configOption.ORIGIN_FILE, Name: name},
+			locations: locations,
+		})
+	})
+}
+
+// applyLayer sets options from keyValues read as l, overriding values set
+// by earlier layers.
+func applyLayer(conf *Config, keyValues map[string]interface{}, l layer) error {
+	l.override = true
+	var errs ValidationErrors
+	errs.add("", conf.checkUnknownKeys(keyValues))
+	errs.add("", conf.parseOneLayer(keyValues, "", l))
+	locateErrors(errs, l.locations)
+	return errs.err()
+}
+
+/*
+ * Public Functions
+ */
+
+func (f SourceFunc) Apply(conf *Config) error {
+	return f(conf)
+}
+
+// BytesSource decodes raw with the decoder registered as format.
+func BytesSource(raw []byte, format string) Source {
+	return bytesSource("", raw, format)
+}
+
+// EnvSource reads an environment variable for every declared option.
+// See Config.EnvName for how variable names are derived from option keys.
+func EnvSource(prefix string) Source {
+	return SourceFunc(func(conf *Config) error {
+		return conf.parseEnv(prefix)
+	})
+}
+
+// FileSource reads the file at path with the decoder registered for its
+// extension.
+func FileSource(path string) Source {
+	return fileSource(path, false)
+}
+
+// FlagSource sets options from the flags set in flagSet whose names are
+// option keys, e.g. flags defined by Config.BindFlags.
+// flagSet must already be parsed. Every invalid flag is reported in
+// ValidationErrors.
+func FlagSource(flagSet *flag.FlagSet) Source {
+	return SourceFunc(func(conf *Config) error {
+		var errs ValidationErrors
+		flagSet.Visit(func(f *flag.Flag) {
+			opt := conf.findOptByKey(f.Name)
+			if opt == nil || opt.ValueType == "nil" || opt.ValueType == "object" {
+				return
+			}
+			value, err := parseString(opt, f.Value.String())
+			if err != nil {
+				errs.add(opt.Key, errors.New(fmt.Sprintf("Invalid flag -%v. %v", f.Name, err)))
+				return
+			}
+			origin := configOption.Origin{Kind: configOption.ORIGIN_FLAG, Name: f.Name}
+			if err := opt.SetValueFrom(value, origin); err != nil {
+				errs.add(opt.Key, errors.New(fmt.Sprintf("Invalid flag -%v. %v", f.Name, err)))
+				return
+			}
+			delete(conf.locations, opt.Key)
+			errs.add(opt.Key, conf.setParents(opt.Key, origin))
+		})
+		return errs.err()
+	})
+}
+
+// KeyValuesSource sets options from decoded key values, e.g. built-in
+// default values of a program.
+func KeyValuesSource(keyValues map[string]interface{}) Source {
+	return SourceFunc(func(conf *Config) error {
+		return applyLayer(conf, keyValues, layer{
+			origin: configOption.Origin{Kind: configOption.ORIGIN_KEY_VALUES},
+		})
+	})
+}
+
+// OptionalFileSource is FileSource that is skipped if the file does not
+// exist, e.g. a per-user config file.
+func OptionalFileSource(path string) Source {
+	return fileSource(path, true)
+}
+
+// Load applies sources in order, so that later sources override values set
+// by earlier ones, then validates the result once. e.g.
+//
+//	conf.Load(
+//		config.KeyValuesSource(defaults),
+//		config.OptionalFileSource("/etc/app/config.json"),
+//		config.OptionalFileSource(userConfigPath),
+//		config.EnvSource("APP"),
+//		config.FlagSource(flag.CommandLine),
+//	)
+func (conf *Config) Load(sources ...Source) error {
+	var errs ValidationErrors
+	for _, source := range sources {
+		errs.add("", source.Apply(conf))
+	}
+	errs.add("", conf.Validate())
+	return errs.err()
+}
@@ -0,0 +1,207 @@
+package config
+
+/*
+ * Module Dependencies
+ */
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/mozzzzy/config/json/configOption"
+	"github.com/mozzzzy/testUtil"
+)
+
+/*
+ * Types
+ */
+
+/*
+ * Constants and Package Scope Variables
+ */
+
+/*
+ * Functions
+ */
+
+func TestLoad(t *testing.T) {
+	t.Run("later sources override earlier ones", func(t *testing.T) {
+		os.Setenv("APP_OBJECT_STRING", "value from env")
+		defer os.Unsetenv("APP_OBJECT_STRING")
+
+		var conf Config
+		addOptionErr := conf.AddOptions([]configOption.Option{
+			{
+				Key:         "float64",
+				ValueType:   "float64",
+				Description: "some description.",
+			},
+			{
+				Key:         "int",
+				ValueType:   "int",
+				Description: "some description.",
+			},
+			{
+				Key:         "string",
+				ValueType:   "string",
+				Description: "some description.",
+			},
+			{
+				Key:         "object",
+				ValueType:   "object",
+				Description: "some description.",
+			},
+			{
+				Key:         "object.int",
+				ValueType:   "int",
+				Description: "some description.",
+			},
+			{
+				Key:         "object.string",
+				ValueType:   "string",
+				Description: "some description.",
+			},
+		})
+		testUtil.NoError(t, addOptionErr)
+
+		flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+		conf.BindFlags(flagSet)
+		flagParseErr := flagSet.Parse([]string{"--object.int=40"})
+		testUtil.NoError(t, flagParseErr)
+
+		loadErr := conf.Load(
+			KeyValuesSource(map[string]interface{}{
+				"float64": float64(0.5),
+				"int":     float64(1),
+				"string":  "default value",
+			}),
+			FileSource(ONE_INT_JSON),
+			OptionalFileSource("testData/not_exist.json"),
+			FileSource(ALL_IN_ONE_JSON),
+			EnvSource("APP"),
+			FlagSource(flagSet),
+		)
+		testUtil.NoError(t, loadErr)
+
+		actualFlt64, getFlt64Err := conf.GetFloat64("float64")
+		testUtil.NoError(t, getFlt64Err)
+		testUtil.Match(t, 1.2, actualFlt64)
+
+		actualInt, getIntErr := conf.GetInt("int")
+		testUtil.NoError(t, getIntErr)
+		testUtil.Match(t, 10, actualInt)
+
+		actualObjInt, getObjIntErr := conf.GetInt("object.int")
+		testUtil.NoError(t, getObjIntErr)
+		testUtil.Match(t, 40, actualObjInt)
+
+		actualObjStr, getObjStrErr := conf.GetString("object.string")
+		testUtil.NoError(t, getObjStrErr)
+		testUtil.Match(t, "value from env", actualObjStr)
+	})
+
+	t.Run("validate only the final result", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "int",
+				ValueType:   "int",
+				Description: "some description.",
+				Required:    true,
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		loadErr := conf.Load(
+			BytesSource([]byte(`{}`), "json"),
+			BytesSource([]byte(`{"int": 10}`), "json"),
+		)
+		testUtil.NoError(t, loadErr)
+	})
+
+	t.Run("invalid (all bad flags are reported)", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions([]configOption.Option{
+			{
+				Key:         "int",
+				ValueType:   "int",
+				Description: "some description.",
+			},
+			{
+				Key:         "int64",
+				ValueType:   "int64",
+				Description: "some description.",
+			},
+		})
+		testUtil.NoError(t, addOptionErr)
+
+		flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+		flagSet.String("int", "", "some description.")
+		flagSet.String("int64", "", "some description.")
+		flagParseErr := flagSet.Parse([]string{"-int=a", "-int64=b"})
+		testUtil.NoError(t, flagParseErr)
+
+		loadErr := conf.Load(FlagSource(flagSet))
+		var validationErrs ValidationErrors
+		testUtil.Match(t, true, errors.As(loadErr, &validationErrs))
+		var keys []string
+		for _, validationErr := range validationErrs {
+			keys = append(keys, validationErr.Key)
+		}
+		testUtil.Match(t, []string{"int", "int64"}, keys)
+	})
+
+	t.Run("invalid (required option is not provided)", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "int",
+				ValueType:   "int",
+				Description: "some description.",
+				Required:    true,
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		loadErr := conf.Load(OptionalFileSource("testData/not_exist.json"))
+		testUtil.WithError(t, loadErr)
+	})
+
+	t.Run("invalid (unknown key in strict mode)", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions([]configOption.Option{
+			{
+				Key:         "name",
+				ValueType:   "string",
+				Description: "some description.",
+			},
+			{
+				Key:         "port",
+				ValueType:   "int",
+				Description: "some description.",
+				Required:    true,
+			},
+		})
+		testUtil.NoError(t, addOptionErr)
+
+		conf.SetStrict(true)
+		loadErr := conf.Load(BytesSource([]byte(`{"port": 80, "nmae": "x"}`), "json"))
+		testUtil.WithError(t, loadErr)
+		var validationErrs ValidationErrors
+		if errors.As(loadErr, &validationErrs) {
+			testUtil.Match(t, []string{"nmae"}, keysOf(validationErrs))
+		}
+
+		actual, getErr := conf.GetInt("port")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, 80, actual)
+	})
+
+	t.Run("invalid (file does not exist)", func(t *testing.T) {
+		var conf Config
+		loadErr := conf.Load(FileSource("testData/not_exist.json"))
+		testUtil.WithError(t, loadErr)
+	})
+}
@@ -0,0 +1,198 @@
+package config
+
+/*
+ * Module Dependencies
+ */
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mozzzzy/config/json/configOption"
+)
+
+/*
+ * Types
+ */
+
+/*
+ * Constants and Package Scope Variables
+ */
+
+/*
+ * Package Private Functions
+ */
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = prev[j-1] + cost
+			if prev[j]+1 < curr[j] {
+				curr[j] = prev[j] + 1
+			}
+			if curr[j-1]+1 < curr[j] {
+				curr[j] = curr[j-1] + 1
+			}
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// patternInstance replaces the "*" elements of pattern with the elements of
+// key at the same positions, e.g. "plugins.auth.enabled" for
+// "plugins.*.enabled" and "plugins.auth.enabeld". It returns "" if pattern
+// and key have different numbers of elements.
+func patternInstance(pattern string, key string) string {
+	patternElems := strings.Split(pattern, ".")
+	keyElems := strings.Split(key, ".")
+	if len(patternElems) != len(keyElems) {
+		return ""
+	}
+	instanceElems := make([]string, 0, len(patternElems))
+	for index, patternElem := range patternElems {
+		if patternElem == "*" {
+			patternElem = keyElems[index]
+		}
+		instanceElems = append(instanceElems, patternElem)
+	}
+	return strings.Join(instanceElems, ".")
+}
+
+// suggestKey returns the declared key closest to key, or "" if no key is
+// close enough to be a likely typo. Pattern options are compared as
+// instances for key.
+func (conf Config) suggestKey(key string) string {
+	suggestion := ""
+	minDistance := len(key)/3 + 2
+	for _, opt := range conf.options {
+		if conf.isElemOption(opt.Key) {
+			continue
+		}
+		candidate := opt.Key
+		if isPattern(opt.Key) {
+			if candidate = patternInstance(opt.Key, key); candidate == "" {
+				continue
+			}
+		}
+		if distance := editDistance(key, candidate); distance < minDistance {
+			suggestion = candidate
+			minDistance = distance
+		}
+	}
+	return suggestion
+}
+
+// findOptOrPattern returns the option declared for key, or the pattern
+// option matching key. As instantiate does, an object option is returned
+// for the undeclared parent of a wildcard element.
+func (conf Config) findOptOrPattern(key string) *configOption.Option {
+	if opt := conf.findOptByKey(key); opt != nil {
+		return opt
+	}
+	keyElems := strings.Split(key, ".")
+	var parentOpt *configOption.Option
+	for index := 0; index < len(conf.options); index++ {
+		opt := &conf.options[index]
+		patternElems := strings.Split(opt.Key, ".")
+		if len(patternElems) < len(keyElems) ||
+			!isPattern(strings.Join(patternElems[:len(keyElems)], ".")) ||
+			!matchKeyElems(patternElems[:len(keyElems)], keyElems) {
+			continue
+		}
+		if len(patternElems) == len(keyElems) {
+			return opt
+		}
+		parentOpt = &configOption.Option{Key: key, ValueType: "object"}
+	}
+	return parentOpt
+}
+
+// unknownKeys adds a failure for every key in kvs without a matching option
+// to errs. pathPrefix is prepended to the keys of the failures.
+func (conf Config) unknownKeys(kvs map[string]interface{}, parentKey string, pathPrefix string, errs *ValidationErrors) {
+	var keys []string
+	for key := range kvs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		absolutePath := joinKey(parentKey, key)
+		opt := conf.findOptOrPattern(absolutePath)
+		if opt == nil {
+			msg := "Unknown key."
+			if suggestion := conf.suggestKey(absolutePath); suggestion != "" {
+				msg += fmt.Sprintf(" Did you mean \"%v%v\"?", pathPrefix, suggestion)
+			}
+			errs.add(pathPrefix+absolutePath, errors.New(msg))
+			continue
+		}
+		// Values of wrong types are reported by parseOneLayer.
+		switch opt.ValueType {
+		case "object":
+			if nextKvs, ok := kvs[key].(map[string]interface{}); ok {
+				conf.unknownKeys(nextKvs, absolutePath, pathPrefix, errs)
+			}
+		case "array<object>":
+			elemConf, _ := conf.GetObject(opt.Key)
+			ary, _ := kvs[key].([]interface{})
+			for index, elem := range ary {
+				if elemKvs, ok := elem.(map[string]interface{}); ok {
+					elemPrefix := fmt.Sprintf("%v%v[%v].", pathPrefix, absolutePath, index)
+					elemConf.unknownKeys(elemKvs, "", elemPrefix, errs)
+				}
+			}
+		case "map<object>":
+			elemConf, _ := conf.GetObject(opt.Key)
+			mp, _ := kvs[key].(map[string]interface{})
+			var mapKeys []string
+			for mapKey := range mp {
+				mapKeys = append(mapKeys, mapKey)
+			}
+			sort.Strings(mapKeys)
+			for _, mapKey := range mapKeys {
+				if elemKvs, ok := mp[mapKey].(map[string]interface{}); ok {
+					elemPrefix := fmt.Sprintf("%v%v.%v.", pathPrefix, absolutePath, mapKey)
+					elemConf.unknownKeys(elemKvs, "", elemPrefix, errs)
+				}
+			}
+		}
+	}
+}
+
+// checkUnknownKeys reports all keys of kvs without a matching option as
+// ValidationErrors in strict mode.
+func (conf Config) checkUnknownKeys(kvs map[string]interface{}) error {
+	if !conf.strict {
+		return nil
+	}
+	var errs ValidationErrors
+	conf.unknownKeys(kvs, "", "", &errs)
+	return errs.err()
+}
+
+/*
+ * Public Functions
+ */
+
+// SetStrict makes Parse and its variants reject keys without a matching
+// option instead of ignoring them. The error lists every unknown key with
+// the closest declared key as a suggestion.
+func (conf *Config) SetStrict(strict bool) {
+	conf.strict = strict
+}
@@ -0,0 +1,164 @@
+package config
+
+/*
+ * Module Dependencies
+ */
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mozzzzy/config/json/configOption"
+	"github.com/mozzzzy/testUtil"
+)
+
+/*
+ * Types
+ */
+
+/*
+ * Constants and Package Scope Variables
+ */
+
+/*
+ * Functions
+ */
+
+func TestSetStrict(t *testing.T) {
+	opts := []configOption.Option{
+		{
+			Key:         "object",
+			ValueType:   "object",
+			Description: "some description.",
+		},
+		{
+			Key:         "object.timeout",
+			ValueType:   "duration",
+			Description: "some description.",
+		},
+		{
+			Key:         "servers",
+			ValueType:   "array<object>",
+			Description: "some description.",
+		},
+		{
+			Key:         "servers.host",
+			ValueType:   "string",
+			Description: "some description.",
+		},
+		{
+			Key:         "timeout",
+			ValueType:   "duration",
+			Description: "some description.",
+		},
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions(opts)
+		testUtil.NoError(t, addOptionErr)
+
+		conf.SetStrict(true)
+		parseErr := conf.ParseBytes([]byte(`{"timeout": "1s", "servers": [{"host": "a"}]}`), "json")
+		testUtil.NoError(t, parseErr)
+	})
+
+	t.Run("unknown keys are ignored without strict mode", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions(opts)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.ParseBytes([]byte(`{"tiemout": "1s"}`), "json")
+		testUtil.NoError(t, parseErr)
+	})
+
+	t.Run("invalid (unknown keys)", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions(opts)
+		testUtil.NoError(t, addOptionErr)
+
+		conf.SetStrict(true)
+		parseErr := conf.ParseBytes([]byte(`{
+			"tiemout": "1s",
+			"object": {"timeuot": "1s"},
+			"servers": [{"host": "a"}, {"hots": "b"}],
+			"unrelated": true
+		}`), "json")
+		testUtil.WithError(t, parseErr)
+		var validationErrs ValidationErrors
+		if errors.As(parseErr, &validationErrs) {
+			var msgs []string
+			for _, validationErr := range validationErrs {
+				msgs = append(msgs, validationErr.Key+": "+validationErr.Err.Error())
+			}
+			expect := "object.timeuot: Unknown key. Did you mean \"object.timeout\"?\n" +
+				"servers[1].hots: Unknown key. Did you mean \"servers[1].host\"?\n" +
+				"tiemout: Unknown key. Did you mean \"timeout\"?\n" +
+				"unrelated: Unknown key."
+			testUtil.Match(t, expect, strings.Join(msgs, "\n"))
+		}
+	})
+
+	t.Run("keys matching patterns are known", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions([]configOption.Option{
+			{
+				Key:         "plugins",
+				ValueType:   "object",
+				Description: "some description.",
+			},
+			{
+				Key:         "plugins.*.enabled",
+				ValueType:   "bool",
+				Description: "some description.",
+			},
+		})
+		testUtil.NoError(t, addOptionErr)
+
+		conf.SetStrict(true)
+		parseErr := conf.ParseBytes([]byte(`{"plugins": {"auth": {"enabled": true}}}`), "json")
+		testUtil.NoError(t, parseErr)
+
+		parseErr = conf.ParseBytes([]byte(`{"plugins": {"auth": {"path": "/opt"}}}`), "json")
+		testUtil.WithError(t, parseErr)
+	})
+
+	t.Run("keys matching patterns are suggested", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions([]configOption.Option{
+			{
+				Key:         "plugins",
+				ValueType:   "object",
+				Description: "some description.",
+			},
+			{
+				Key:         "plugins.*.enabled",
+				ValueType:   "bool",
+				Description: "some description.",
+			},
+		})
+		testUtil.NoError(t, addOptionErr)
+
+		conf.SetStrict(true)
+		parseErr := conf.ParseBytes([]byte(`{"plugins": {"auth": {"enabeld": true}}}`), "json")
+		testUtil.WithError(t, parseErr)
+		var validationErrs ValidationErrors
+		if errors.As(parseErr, &validationErrs) {
+			testUtil.Match(t, 1, len(validationErrs))
+			testUtil.Match(t, "plugins.auth.enabeld", validationErrs[0].Key)
+			testUtil.Match(t,
+				"Unknown key. Did you mean \"plugins.auth.enabled\"?",
+				validationErrs[0].Err.Error())
+		}
+	})
+}
+
+func TestEditDistance(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		testUtil.Match(t, 0, editDistance("timeout", "timeout"))
+		testUtil.Match(t, 2, editDistance("tiemout", "timeout"))
+		testUtil.Match(t, 3, editDistance("kitten", "sitting"))
+		testUtil.Match(t, 7, editDistance("", "timeout"))
+	})
+}
@@ -0,0 +1,165 @@
+package config
+
+/*
+ * Module Dependencies
+ */
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mozzzzy/config/json/configOption"
+)
+
+/*
+ * Types
+ */
+
+// Watcher reparses a config file whenever it changes. See Config.Watch.
+type Watcher struct {
+	template Config
+	path     string
+	interval time.Duration
+	onError  func(error)
+	// *Config parsed last without errors
+	current   atomic.Value
+	lastState fileState
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// fileState is what polling compares to find changes of a file.
+type fileState struct {
+	exists  bool
+	modTime time.Time
+	size    int64
+}
+
+/*
+ * Constants and Package Scope Variables
+ */
+
+/*
+ * Package Private Functions
+ */
+
+// clone returns a copy of conf whose options have no values, so that it can
+// be parsed without touching conf.
+func (conf Config) clone() Config {
+	cloned := conf
+	cloned.options = make([]configOption.Option, len(conf.options))
+	for index, opt := range conf.options {
+		opt.Unset()
+		cloned.options[index] = opt
+	}
+	cloned.locations = nil
+	return cloned
+}
+
+func stateOf(path string) (fileState, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileState{}, err
+	}
+	return fileState{
+		exists:  true,
+		modTime: info.ModTime(),
+		size:    info.Size(),
+	}, nil
+}
+
+// load parses the file into a fresh copy of the template, and swaps it in
+// only if it is valid.
+func (watcher *Watcher) load() error {
+	fresh := watcher.template.clone()
+	if err := fresh.Parse(watcher.path); err != nil {
+		return err
+	}
+	watcher.current.Store(&fresh)
+	return nil
+}
+
+// poll reloads the file if its modification time or size has changed since
+// the last poll.
+func (watcher *Watcher) poll() {
+	state, err := stateOf(watcher.path)
+	if state == watcher.lastState {
+		return
+	}
+	watcher.lastState = state
+	if err != nil {
+		watcher.onError(err)
+		return
+	}
+	if err := watcher.load(); err != nil {
+		watcher.onError(err)
+	}
+}
+
+func (watcher *Watcher) run() {
+	defer close(watcher.done)
+	ticker := time.NewTicker(watcher.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-watcher.stop:
+			return
+		case <-ticker.C:
+			watcher.poll()
+		}
+	}
+}
+
+/*
+ * Public Functions
+ */
+
+// Watch parses the file at path as Parse does, then checks every interval
+// whether the file has changed. A changed file is parsed into a fresh copy
+// of conf, which replaces the config returned by Watcher.Config only if it
+// is valid. Otherwise the previous config is kept and the failure is passed
+// to onError, which may be nil. conf declares the options and bindings of
+// every copy, and should not be parsed itself.
+func (conf Config) Watch(path string, interval time.Duration, onError func(error)) (*Watcher, error) {
+	if interval <= 0 {
+		return nil, errors.New(fmt.Sprintf("Interval of Watch must be positive. But specified value is %v.", interval))
+	}
+	if onError == nil {
+		onError = func(error) {}
+	}
+	watcher := &Watcher{
+		template: conf.clone(),
+		path:     path,
+		interval: interval,
+		onError:  onError,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	// Take the state first, so that a change during the first load is
+	// picked up by the next poll.
+	watcher.lastState, _ = stateOf(path)
+	if err := watcher.load(); err != nil {
+		return nil, err
+	}
+	go watcher.run()
+	return watcher, nil
+}
+
+// Close stops watching. The config returned by Config stays usable.
+func (watcher *Watcher) Close() {
+	watcher.closeOnce.Do(func() {
+		close(watcher.stop)
+	})
+	<-watcher.done
+}
+
+// Config returns the config parsed last without errors. Don't modify it, as
+// it may be shared by other goroutines.
+func (watcher *Watcher) Config() *Config {
+	return watcher.current.Load().(*Config)
+}
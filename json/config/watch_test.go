@@ -0,0 +1,125 @@
+package config
+
+/*
+ * Module Dependencies
+ */
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mozzzzy/config/json/configOption"
+	"github.com/mozzzzy/testUtil"
+)
+
+/*
+ * Types
+ */
+
+/*
+ * Constants and Package Scope Variables
+ */
+
+/*
+ * Functions
+ */
+
+// writeConfigFile writes content to path with a modification time later
+// than the previous write, so that polling sees the change.
+func writeConfigFile(t *testing.T, path string, content string, modTime time.Time) {
+	writeErr := ioutil.WriteFile(path, []byte(content), 0644)
+	testUtil.NoError(t, writeErr)
+	chtimesErr := os.Chtimes(path, modTime, modTime)
+	testUtil.NoError(t, chtimesErr)
+}
+
+// waitFor polls condition until it holds or a second passes.
+func waitFor(condition func() bool) bool {
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); {
+		if condition() {
+			return true
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return condition()
+}
+
+func TestWatch(t *testing.T) {
+	var conf Config
+	addOptionErr := conf.AddOption(
+		configOption.Option{
+			Key:         "int",
+			ValueType:   "int",
+			Description: "some description.",
+			Required:    true,
+		},
+	)
+	testUtil.NoError(t, addOptionErr)
+
+	t.Run("valid change is swapped in", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.json")
+		now := time.Now()
+		writeConfigFile(t, path, `{"int": 10}`, now)
+
+		watcher, watchErr := conf.Watch(path, 5*time.Millisecond, nil)
+		testUtil.NoError(t, watchErr)
+		defer watcher.Close()
+
+		actual, getErr := watcher.Config().GetInt("int")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, 10, actual)
+
+		writeConfigFile(t, path, `{"int": 20}`, now.Add(time.Second))
+		testUtil.Match(t, true, waitFor(func() bool {
+			actual, _ := watcher.Config().GetInt("int")
+			return actual == 20
+		}))
+	})
+
+	t.Run("invalid change keeps previous config", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.json")
+		now := time.Now()
+		writeConfigFile(t, path, `{"int": 10}`, now)
+
+		errs := make(chan error, 10)
+		watcher, watchErr := conf.Watch(path, 5*time.Millisecond, func(err error) {
+			errs <- err
+		})
+		testUtil.NoError(t, watchErr)
+		defer watcher.Close()
+
+		writeConfigFile(t, path, `{"int": "abc"}`, now.Add(time.Second))
+		select {
+		case err := <-errs:
+			testUtil.WithError(t, err)
+		case <-time.After(time.Second):
+			t.Errorf("invalid change is not reported.")
+		}
+		actual, getErr := watcher.Config().GetInt("int")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, 10, actual)
+	})
+
+	t.Run("invalid (first parse fails)", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.json")
+		writeConfigFile(t, path, `{}`, time.Now())
+
+		_, watchErr := conf.Watch(path, 5*time.Millisecond, nil)
+		testUtil.WithError(t, watchErr)
+	})
+
+	t.Run("invalid (interval is not positive)", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.json")
+		writeConfigFile(t, path, `{"int": 10}`, time.Now())
+
+		_, watchErr := conf.Watch(path, 0, nil)
+		testUtil.WithError(t, watchErr)
+	})
+
+	t.Run("template is not parsed", func(t *testing.T) {
+		testUtil.Match(t, false, conf.findOptByKey("int").IsSet())
+	})
+}
@@ -7,6 +7,11 @@ package configOption
 import (
 	"errors"
 	"fmt"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
 )
 
 /*
@@ -23,6 +28,14 @@ type Option struct {
 	set            bool
 	Validator      func(interface{}, interface{}) error
 	ValidatorParam interface{}
+	AllowedValues  []interface{}
+	// Length constraints of array values. MaxLen 0 means no limit.
+	MinLen int
+	MaxLen int
+	// Origin is where Value came from, and Overridden are where the values
+	// it replaced came from, oldest first.
+	Origin     Origin
+	Overridden []Origin
 }
 
 /*
@@ -33,6 +46,77 @@ type Option struct {
  * Package Private Functions
  */
 
+func (opt Option) isAllowed(value interface{}) bool {
+	// AllowedValues of typed arrays and maps are checked for each element.
+	if _, ok := ElementType(opt.ValueType); ok || len(opt.AllowedValues) == 0 {
+		return true
+	}
+	if _, ok := MapValueType(opt.ValueType); ok {
+		return true
+	}
+	for _, allowedValue := range opt.AllowedValues {
+		if reflect.DeepEqual(allowedValue, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (opt Option) checkLen(ary []interface{}) error {
+	if len(ary) < opt.MinLen {
+		return errors.New(
+			fmt.Sprintf(
+				"Option %v requires at least %v elements. But %v elements are specified.",
+				opt.Key, opt.MinLen, len(ary)))
+	}
+	if opt.MaxLen > 0 && len(ary) > opt.MaxLen {
+		return errors.New(
+			fmt.Sprintf(
+				"Option %v accepts at most %v elements. But %v elements are specified.",
+				opt.Key, opt.MaxLen, len(ary)))
+	}
+	return nil
+}
+
+func (opt Option) checkElem(key string, elemType string, elem interface{}) error {
+	elemOpt := Option{
+		Key:           key,
+		ValueType:     elemType,
+		AllowedValues: opt.AllowedValues,
+	}
+	if elem == nil {
+		return errors.New(fmt.Sprintf("Element %v is null.", elemOpt.Key))
+	}
+	return elemOpt.SetValue(elem)
+}
+
+func (opt Option) checkElems(elemType string, ary []interface{}) error {
+	for index, elem := range ary {
+		if err := opt.checkElem(fmt.Sprintf("%v[%v]", opt.Key, index), elemType, elem); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (opt Option) checkMapValues(valueType string, mp map[string]interface{}) error {
+	for key, value := range mp {
+		if err := opt.checkElem(opt.Key+"."+key, valueType, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// typeParam returns T of a ValueType "<container><T>".
+func typeParam(container string, valueType string) (string, bool) {
+	if !strings.HasPrefix(valueType, container+"<") || !strings.HasSuffix(valueType, ">") {
+		return "", false
+	}
+	param := valueType[len(container)+1 : len(valueType)-1]
+	return param, param != ""
+}
+
 func validateRule(opt Option) error {
 	if opt.Key == "" {
 		return errors.New("Key is required.")
@@ -43,16 +127,69 @@ func validateRule(opt Option) error {
 				"Required option %v can't be specified its default value.",
 				opt.Key))
 	}
+	if opt.DefaultValue != nil && !opt.isAllowed(opt.DefaultValue) {
+		return errors.New(
+			fmt.Sprintf(
+				"Default value %v of option %v is not one of %v.",
+				opt.DefaultValue, opt.Key, opt.AllowedValues))
+	}
+	if elemType, ok := ElementType(opt.ValueType); ok && opt.DefaultValue != nil {
+		ary, ok := opt.DefaultValue.([]interface{})
+		if !ok {
+			return errors.New(fmt.Sprintf("Invalid []interface{} default value %v.", opt.DefaultValue))
+		}
+		if err := opt.checkElems(elemType, ary); err != nil {
+			return err
+		}
+		if err := opt.checkLen(ary); err != nil {
+			return err
+		}
+	}
+	if valueType, ok := MapValueType(opt.ValueType); (ok || opt.ValueType == "map") && opt.DefaultValue != nil {
+		mp, ok := opt.DefaultValue.(map[string]interface{})
+		if !ok {
+			return errors.New(fmt.Sprintf("Invalid map[string]interface{} default value %v.", opt.DefaultValue))
+		}
+		if valueType != "" {
+			if err := opt.checkMapValues(valueType, mp); err != nil {
+				return err
+			}
+		}
+	}
 	if opt.DefaultValue != nil {
 		switch opt.ValueType {
 		case "array":
-			if val, ok := opt.DefaultValue.([]interface{}); !ok {
+			val, ok := opt.DefaultValue.([]interface{})
+			if !ok {
 				return errors.New(fmt.Sprintf("Invalid []interface{} default value %v.", val))
 			}
+			if err := opt.checkLen(val); err != nil {
+				return err
+			}
+		case "bool":
+			if val, ok := opt.DefaultValue.(bool); !ok {
+				return errors.New(fmt.Sprintf("Invalid bool default value %v.", val))
+			}
+		case "bytesize":
+			if val, ok := opt.DefaultValue.(int64); !ok {
+				return errors.New(fmt.Sprintf("Invalid bytesize (int64) default value %v.", val))
+			}
+		case "cidr":
+			if val, ok := opt.DefaultValue.(netip.Prefix); !ok {
+				return errors.New(fmt.Sprintf("Invalid netip.Prefix default value %v.", val))
+			}
+		case "duration":
+			if val, ok := opt.DefaultValue.(time.Duration); !ok {
+				return errors.New(fmt.Sprintf("Invalid time.Duration default value %v.", val))
+			}
 		case "float64":
 			if val, ok := opt.DefaultValue.(float64); !ok {
 				return errors.New(fmt.Sprintf("Invalid float64 default value %v.", val))
 			}
+		case "hostport":
+			if val, ok := opt.DefaultValue.(netip.AddrPort); !ok {
+				return errors.New(fmt.Sprintf("Invalid netip.AddrPort default value %v.", val))
+			}
 		case "int":
 			if val, ok := opt.DefaultValue.(int); !ok {
 				return errors.New(fmt.Sprintf("Invalid int default value %v.", val))
@@ -61,10 +198,22 @@ func validateRule(opt Option) error {
 			if val, ok := opt.DefaultValue.(int64); !ok {
 				return errors.New(fmt.Sprintf("Invalid int64 default value %v.", val))
 			}
+		case "ip":
+			if val, ok := opt.DefaultValue.(netip.Addr); !ok {
+				return errors.New(fmt.Sprintf("Invalid netip.Addr default value %v.", val))
+			}
 		case "string":
 			if val, ok := opt.DefaultValue.(string); !ok {
 				return errors.New(fmt.Sprintf("Invalid string default value %v.", val))
 			}
+		case "time":
+			if val, ok := opt.DefaultValue.(time.Time); !ok {
+				return errors.New(fmt.Sprintf("Invalid time.Time default value %v.", val))
+			}
+		case "url":
+			if val, ok := opt.DefaultValue.(*url.URL); !ok || val == nil {
+				return errors.New(fmt.Sprintf("Invalid *url.URL default value %v.", val))
+			}
 		}
 	}
 	return nil
@@ -74,12 +223,21 @@ func validateRule(opt Option) error {
  * Public Functions
  */
 
+// ElementType returns the element type T of a typed array ValueType
+// "array<T>" (e.g. "array<string>").
+func ElementType(valueType string) (string, bool) {
+	return typeParam("array", valueType)
+}
+
+// MapValueType returns the value type T of a typed map ValueType "map<T>"
+// (e.g. "map<int>"). Keys of maps are always strings.
+func MapValueType(valueType string) (string, bool) {
+	return typeParam("map", valueType)
+}
+
 func (opt *Option) GetValue() (interface{}, error) {
 	if !opt.set && opt.DefaultValue == nil {
-		return nil, errors.New(
-			fmt.Sprintf(
-				"No value and no default value for %v are set.",
-				opt.Key))
+		return nil, fmt.Errorf("%w: %v", ErrNoValue, opt.Key)
 	}
 	if !opt.set {
 		return opt.DefaultValue, nil
@@ -91,6 +249,15 @@ func (opt Option) IsSet() bool {
 	return opt.set
 }
 
+// Unset clears the value of opt and its origins, so that its default value
+// is used again.
+func (opt *Option) Unset() {
+	opt.Value = nil
+	opt.set = false
+	opt.Origin = Origin{}
+	opt.Overridden = nil
+}
+
 func New(opt Option) (*Option, error) {
 	// Validate
 	if err := validateRule(opt); err != nil {
@@ -105,67 +272,207 @@ func New(opt Option) (*Option, error) {
 	return &opt, nil
 }
 
+// SetValue sets value, recording ORIGIN_SET as its Origin.
 func (opt *Option) SetValue(value interface{}) error {
+	return opt.SetValueFrom(value, Origin{Kind: ORIGIN_SET})
+}
+
+func (opt *Option) setValue(value interface{}) error {
 	if value == nil {
 		return errors.New("nil is invalid for SetValue func's param.")
 	}
+	if !opt.isAllowed(value) {
+		return errors.New(
+			fmt.Sprintf(
+				"Failed to SetValue to option. "+
+					"Value %v of option %v is not one of %v.",
+				value, opt.Key, opt.AllowedValues))
+	}
+	if elemType, ok := ElementType(opt.ValueType); ok {
+		ary, ok := value.([]interface{})
+		if !ok {
+			return &TypeMismatchError{
+				Key:  opt.Key,
+				Want: opt.ValueType,
+				Got:  fmt.Sprintf("%T", value),
+			}
+		}
+		if err := opt.checkElems(elemType, ary); err != nil {
+			return err
+		}
+		if err := opt.checkLen(ary); err != nil {
+			return err
+		}
+		opt.Value = ary
+		opt.set = true
+		return nil
+	}
+	if valueType, ok := MapValueType(opt.ValueType); ok || opt.ValueType == "map" {
+		mp, ok := value.(map[string]interface{})
+		if !ok {
+			return &TypeMismatchError{
+				Key:  opt.Key,
+				Want: opt.ValueType,
+				Got:  fmt.Sprintf("%T", value),
+			}
+		}
+		if valueType != "" {
+			if err := opt.checkMapValues(valueType, mp); err != nil {
+				return err
+			}
+		}
+		opt.Value = mp
+		opt.set = true
+		return nil
+	}
 	switch opt.ValueType {
 	case "":
 	case "nil":
 	case "array":
 		ary, ok := value.([]interface{})
 		if ok {
+			if err := opt.checkLen(ary); err != nil {
+				return err
+			}
 			opt.Value = ary
 		} else {
-			return errors.New(
-				fmt.Sprintf(
-					"Failed to SetValue to option. "+
-						"The ValueType is array ([]interface{}). "+
-						"But specified value is %T.", value))
+			return &TypeMismatchError{
+				Key:  opt.Key,
+				Want: opt.ValueType,
+				Got:  fmt.Sprintf("%T", value),
+			}
+		}
+	case "bool":
+		boolean, ok := value.(bool)
+		if ok {
+			opt.Value = boolean
+		} else {
+			return &TypeMismatchError{
+				Key:  opt.Key,
+				Want: opt.ValueType,
+				Got:  fmt.Sprintf("%T", value),
+			}
+		}
+	case "bytesize":
+		integer64, ok := value.(int64)
+		if ok {
+			opt.Value = integer64
+		} else {
+			return &TypeMismatchError{
+				Key:  opt.Key,
+				Want: opt.ValueType,
+				Got:  fmt.Sprintf("%T", value),
+			}
+		}
+	case "cidr":
+		prefix, ok := value.(netip.Prefix)
+		if ok {
+			opt.Value = prefix
+		} else {
+			return &TypeMismatchError{
+				Key:  opt.Key,
+				Want: opt.ValueType,
+				Got:  fmt.Sprintf("%T", value),
+			}
+		}
+	case "duration":
+		duration, ok := value.(time.Duration)
+		if ok {
+			opt.Value = duration
+		} else {
+			return &TypeMismatchError{
+				Key:  opt.Key,
+				Want: opt.ValueType,
+				Got:  fmt.Sprintf("%T", value),
+			}
 		}
 	case "float64":
 		flt64, ok := value.(float64)
 		if ok {
 			opt.Value = flt64
 		} else {
-			return errors.New(
-				fmt.Sprintf(
-					"Failed to SetValue to option. "+
-						"The ValueType is float64. "+
-						"But specified value is %T.", value))
+			return &TypeMismatchError{
+				Key:  opt.Key,
+				Want: opt.ValueType,
+				Got:  fmt.Sprintf("%T", value),
+			}
+		}
+	case "hostport":
+		addrPort, ok := value.(netip.AddrPort)
+		if ok {
+			opt.Value = addrPort
+		} else {
+			return &TypeMismatchError{
+				Key:  opt.Key,
+				Want: opt.ValueType,
+				Got:  fmt.Sprintf("%T", value),
+			}
 		}
 	case "int":
 		integer, ok := value.(int)
 		if ok {
 			opt.Value = integer
 		} else {
-			return errors.New(
-				fmt.Sprintf(
-					"Failed to SetValue to option. "+
-						"The ValueType is int. "+
-						"But specified value is %T.", value))
+			return &TypeMismatchError{
+				Key:  opt.Key,
+				Want: opt.ValueType,
+				Got:  fmt.Sprintf("%T", value),
+			}
 		}
 	case "int64":
 		integer64, ok := value.(int64)
 		if ok {
 			opt.Value = integer64
 		} else {
-			return errors.New(
-				fmt.Sprintf(
-					"Failed to SetValue to option. "+
-						"The ValueType is int64. "+
-						"But specified value is %T.", value))
+			return &TypeMismatchError{
+				Key:  opt.Key,
+				Want: opt.ValueType,
+				Got:  fmt.Sprintf("%T", value),
+			}
+		}
+	case "ip":
+		addr, ok := value.(netip.Addr)
+		if ok {
+			opt.Value = addr
+		} else {
+			return &TypeMismatchError{
+				Key:  opt.Key,
+				Want: opt.ValueType,
+				Got:  fmt.Sprintf("%T", value),
+			}
 		}
 	case "string":
 		str, ok := value.(string)
 		if ok {
 			opt.Value = str
 		} else {
-			return errors.New(
-				fmt.Sprintf(
-					"Failed to SetValue to option. "+
-						"The ValueType is string. "+
-						"But specified value is %T.", value))
+			return &TypeMismatchError{
+				Key:  opt.Key,
+				Want: opt.ValueType,
+				Got:  fmt.Sprintf("%T", value),
+			}
+		}
+	case "time":
+		tm, ok := value.(time.Time)
+		if ok {
+			opt.Value = tm
+		} else {
+			return &TypeMismatchError{
+				Key:  opt.Key,
+				Want: opt.ValueType,
+				Got:  fmt.Sprintf("%T", value),
+			}
+		}
+	case "url":
+		urlPtr, ok := value.(*url.URL)
+		if ok {
+			opt.Value = urlPtr
+		} else {
+			return &TypeMismatchError{
+				Key:  opt.Key,
+				Want: opt.ValueType,
+				Got:  fmt.Sprintf("%T", value),
+			}
 		}
 	}
 	opt.set = true
@@ -175,13 +482,17 @@ func (opt *Option) SetValue(value interface{}) error {
 func (opt Option) Validate() error {
 	// Required but not set
 	if opt.Required && opt.set == false {
-		return errors.New(
-			fmt.Sprintf("Required option %v is not provided.", opt.Key))
+		return fmt.Errorf("%w: %v", ErrRequiredMissing, opt.Key)
 	}
 
-	// Execute validator
-	if opt.Validator != nil {
-		return opt.Validator(opt.Value, opt.ValidatorParam)
+	// Execute validator on the value in effect, which may be the default
+	// value. Options without any value have nothing to validate.
+	if opt.Validator != nil && (opt.set || opt.DefaultValue != nil) {
+		value, err := opt.GetValue()
+		if err != nil {
+			return err
+		}
+		return opt.Validator(value, opt.ValidatorParam)
 	}
 	return nil
 }
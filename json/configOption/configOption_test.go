@@ -5,7 +5,9 @@ package configOption
  */
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/mozzzzy/testUtil"
 	"github.com/mozzzzy/config/validator"
@@ -80,6 +82,16 @@ func TestNew(t *testing.T) {
 		testUtil.NoError(t, err)
 	})
 
+	t.Run("invalid option (bool option with string default value)", func(t *testing.T) {
+		_, err := New(Option{
+			Key: "bool",
+			ValueType: "bool",
+			Description: "some bool value",
+			DefaultValue: "true",
+		})
+		testUtil.WithError(t, err)
+	})
+
 	t.Run("invalid option (no key)", func(t *testing.T) {
 		_, err := New(Option{
 			Key: "",
@@ -112,6 +124,17 @@ func TestNew(t *testing.T) {
 		})
 		testUtil.NoError(t, err)
 	})
+
+	t.Run("invalid option (default value is not allowed)", func(t *testing.T) {
+		_, err := New(Option{
+			Key: "string",
+			ValueType: "string",
+			Description: "some string value",
+			DefaultValue: "trace",
+			AllowedValues: []interface{}{"debug", "info", "warn"},
+		})
+		testUtil.WithError(t, err)
+	})
 }
 
 func TestSetValue(t *testing.T) {
@@ -188,6 +211,255 @@ func TestSetValue(t *testing.T) {
 		testUtil.NoError(t, getValueErr)
 		testUtil.Match(t, expected, actual)
 	})
+
+	t.Run("set value to a bool option", func(t *testing.T) {
+		opt, newErr := New(Option{
+			Key: "bool",
+			ValueType: "bool",
+			Description: "some bool value",
+		})
+		testUtil.NoError(t, newErr)
+
+		setValueErr := opt.SetValue(true)
+		testUtil.NoError(t, setValueErr)
+		testUtil.Match(t, true, opt.IsSet())
+
+		var expected interface{} = true
+		actual, getValueErr := opt.GetValue()
+		testUtil.NoError(t, getValueErr)
+		testUtil.Match(t, expected, actual)
+	})
+
+	t.Run("invalid (set string value to a bool option)", func(t *testing.T) {
+		opt, newErr := New(Option{
+			Key: "bool",
+			ValueType: "bool",
+			Description: "some bool value",
+		})
+		testUtil.NoError(t, newErr)
+
+		setValueErr := opt.SetValue("true")
+		testUtil.WithError(t, setValueErr)
+
+		var typeMismatchErr *TypeMismatchError
+		testUtil.Match(t, true, errors.As(setValueErr, &typeMismatchErr))
+		if typeMismatchErr != nil {
+			testUtil.Match(t, TypeMismatchError{Key: "bool", Want: "bool", Got: "string"}, *typeMismatchErr)
+		}
+	})
+
+	t.Run("set value to a duration option", func(t *testing.T) {
+		opt, newErr := New(Option{
+			Key: "duration",
+			ValueType: "duration",
+			Description: "some duration value",
+			DefaultValue: time.Second,
+		})
+		testUtil.NoError(t, newErr)
+
+		setValueErr := opt.SetValue(time.Minute)
+		testUtil.NoError(t, setValueErr)
+
+		var expected interface{} = time.Minute
+		actual, getValueErr := opt.GetValue()
+		testUtil.NoError(t, getValueErr)
+		testUtil.Match(t, expected, actual)
+	})
+
+	t.Run("invalid (set int64 value to a duration option)", func(t *testing.T) {
+		opt, newErr := New(Option{
+			Key: "duration",
+			ValueType: "duration",
+			Description: "some duration value",
+		})
+		testUtil.NoError(t, newErr)
+
+		setValueErr := opt.SetValue(int64(1))
+		testUtil.WithError(t, setValueErr)
+	})
+
+	t.Run("set value to a bytesize option", func(t *testing.T) {
+		opt, newErr := New(Option{
+			Key: "bytesize",
+			ValueType: "bytesize",
+			Description: "some bytesize value",
+			DefaultValue: int64(1024),
+		})
+		testUtil.NoError(t, newErr)
+
+		setValueErr := opt.SetValue(int64(2048))
+		testUtil.NoError(t, setValueErr)
+
+		var expected interface{} = int64(2048)
+		actual, getValueErr := opt.GetValue()
+		testUtil.NoError(t, getValueErr)
+		testUtil.Match(t, expected, actual)
+	})
+
+	t.Run("set value to a time option", func(t *testing.T) {
+		opt, newErr := New(Option{
+			Key: "time",
+			ValueType: "time",
+			Description: "some time value",
+		})
+		testUtil.NoError(t, newErr)
+
+		tm := time.Date(2020, 3, 8, 0, 0, 0, 0, time.UTC)
+		setValueErr := opt.SetValue(tm)
+		testUtil.NoError(t, setValueErr)
+		testUtil.Match(t, true, opt.IsSet())
+
+		var expected interface{} = tm
+		actual, getValueErr := opt.GetValue()
+		testUtil.NoError(t, getValueErr)
+		testUtil.Match(t, expected, actual)
+	})
+
+	t.Run("invalid (set string value to a time option)", func(t *testing.T) {
+		opt, newErr := New(Option{
+			Key: "time",
+			ValueType: "time",
+			Description: "some time value",
+		})
+		testUtil.NoError(t, newErr)
+
+		setValueErr := opt.SetValue("2020-03-08")
+		testUtil.WithError(t, setValueErr)
+		testUtil.Match(t, false, opt.IsSet())
+	})
+
+	t.Run("set value to a typed array option", func(t *testing.T) {
+		opt, newErr := New(Option{
+			Key: "array",
+			ValueType: "array<int>",
+			Description: "some array value",
+			MaxLen: 2,
+		})
+		testUtil.NoError(t, newErr)
+
+		setValueErr := opt.SetValue([]interface{}{80, 443})
+		testUtil.NoError(t, setValueErr)
+		testUtil.Match(t, true, opt.IsSet())
+	})
+
+	t.Run("invalid (set string element to an int array option)", func(t *testing.T) {
+		opt, newErr := New(Option{
+			Key: "array",
+			ValueType: "array<int>",
+			Description: "some array value",
+		})
+		testUtil.NoError(t, newErr)
+
+		setValueErr := opt.SetValue([]interface{}{80, "443"})
+		testUtil.WithError(t, setValueErr)
+		testUtil.Match(t, false, opt.IsSet())
+	})
+
+	t.Run("invalid (set too long value to an array option)", func(t *testing.T) {
+		opt, newErr := New(Option{
+			Key: "array",
+			ValueType: "array",
+			Description: "some array value",
+			MaxLen: 1,
+		})
+		testUtil.NoError(t, newErr)
+
+		setValueErr := opt.SetValue([]interface{}{80, 443})
+		testUtil.WithError(t, setValueErr)
+		testUtil.Match(t, false, opt.IsSet())
+	})
+
+	t.Run("set value to a typed map option", func(t *testing.T) {
+		opt, newErr := New(Option{
+			Key: "map",
+			ValueType: "map<int>",
+			Description: "some map value",
+		})
+		testUtil.NoError(t, newErr)
+
+		setValueErr := opt.SetValue(map[string]interface{}{"a": 1, "b": 2})
+		testUtil.NoError(t, setValueErr)
+		testUtil.Match(t, true, opt.IsSet())
+	})
+
+	t.Run("invalid (set string value to an int map option)", func(t *testing.T) {
+		opt, newErr := New(Option{
+			Key: "map",
+			ValueType: "map<int>",
+			Description: "some map value",
+		})
+		testUtil.NoError(t, newErr)
+
+		setValueErr := opt.SetValue(map[string]interface{}{"a": 1, "b": "2"})
+		testUtil.WithError(t, setValueErr)
+		testUtil.Match(t, false, opt.IsSet())
+	})
+
+	t.Run("set allowed value to a string option", func(t *testing.T) {
+		opt, newErr := New(Option{
+			Key: "string",
+			ValueType: "string",
+			Description: "some string value",
+			AllowedValues: []interface{}{"debug", "info", "warn"},
+		})
+		testUtil.NoError(t, newErr)
+
+		setValueErr := opt.SetValue("info")
+		testUtil.NoError(t, setValueErr)
+		testUtil.Match(t, true, opt.IsSet())
+	})
+
+	t.Run("invalid (set not allowed value to a string option)", func(t *testing.T) {
+		opt, newErr := New(Option{
+			Key: "string",
+			ValueType: "string",
+			Description: "some string value",
+			AllowedValues: []interface{}{"debug", "info", "warn"},
+		})
+		testUtil.NoError(t, newErr)
+
+		setValueErr := opt.SetValue("trace")
+		testUtil.WithError(t, setValueErr)
+		testUtil.Match(t, false, opt.IsSet())
+	})
+}
+
+func TestSetValueFrom(t *testing.T) {
+	t.Run("origins are recorded", func(t *testing.T) {
+		opt, newErr := New(Option{
+			Key: "int",
+			ValueType: "int",
+			Description: "some int value",
+		})
+		testUtil.NoError(t, newErr)
+
+		fileOrigin := Origin{Kind: ORIGIN_FILE, Name: "config.json", Line: 2, Column: 3}
+		setValueErr := opt.SetValueFrom(10, fileOrigin)
+		testUtil.NoError(t, setValueErr)
+		envOrigin := Origin{Kind: ORIGIN_ENV, Name: "APP_INT"}
+		setValueErr = opt.SetValueFrom(20, envOrigin)
+		testUtil.NoError(t, setValueErr)
+
+		testUtil.Match(t, envOrigin, opt.Origin)
+		testUtil.Match(t, []Origin{fileOrigin}, opt.Overridden)
+		testUtil.Match(t, "file config.json:2:3", fileOrigin.String())
+	})
+
+	t.Run("origin is kept on failure", func(t *testing.T) {
+		opt, newErr := New(Option{
+			Key: "int",
+			ValueType: "int",
+			Description: "some int value",
+		})
+		testUtil.NoError(t, newErr)
+
+		setValueErr := opt.SetValue(10)
+		testUtil.NoError(t, setValueErr)
+		setValueErr = opt.SetValueFrom("abc", Origin{Kind: ORIGIN_FLAG, Name: "int"})
+		testUtil.WithError(t, setValueErr)
+		testUtil.Match(t, Origin{Kind: ORIGIN_SET}, opt.Origin)
+		testUtil.Match(t, 0, len(opt.Overridden))
+	})
 }
 
 func TestIsSet(t *testing.T) {
@@ -239,6 +511,7 @@ func TestGetValue(t *testing.T) {
 		expected = nil
 		actual, getValueErr := opt.GetValue()
 		testUtil.WithError(t, getValueErr)
+		testUtil.Match(t, true, errors.Is(getValueErr, ErrNoValue))
 		testUtil.Match(t, expected, actual)
 	})
 
@@ -295,3 +568,46 @@ func TestGetValue(t *testing.T) {
 		testUtil.Match(t, expected, actual)
 	})
 }
+
+func TestValidate(t *testing.T) {
+	t.Run("invalid (required option is not set)", func(t *testing.T) {
+		opt, newErr := New(Option{
+			Key: "int",
+			ValueType: "int",
+			Description: "some int value",
+			Required: true,
+		})
+		testUtil.NoError(t, newErr)
+
+		validateErr := opt.Validate()
+		testUtil.Match(t, true, errors.Is(validateErr, ErrRequiredMissing))
+	})
+
+	t.Run("default value is validated", func(t *testing.T) {
+		opt, newErr := New(Option{
+			Key: "duration",
+			ValueType: "duration",
+			Description: "some duration value",
+			DefaultValue: 5 * time.Second,
+			Validator: validator.DurationWithin,
+			ValidatorParam: []time.Duration{time.Second, 10 * time.Second},
+		})
+		testUtil.NoError(t, newErr)
+		testUtil.NoError(t, opt.Validate())
+
+		opt.DefaultValue = 20 * time.Second
+		testUtil.WithError(t, opt.Validate())
+	})
+
+	t.Run("option without any value is not validated", func(t *testing.T) {
+		opt, newErr := New(Option{
+			Key: "duration",
+			ValueType: "duration",
+			Description: "some duration value",
+			Validator: validator.DurationWithin,
+			ValidatorParam: []time.Duration{time.Second, 10 * time.Second},
+		})
+		testUtil.NoError(t, newErr)
+		testUtil.NoError(t, opt.Validate())
+	})
+}
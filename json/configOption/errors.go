@@ -0,0 +1,50 @@
+package configOption
+
+/*
+ * Module Dependencies
+ */
+
+import (
+	"errors"
+	"fmt"
+)
+
+/*
+ * Types
+ */
+
+// TypeMismatchError is returned when a value of the option Key is not of
+// the type Want. Got is the type of the value.
+type TypeMismatchError struct {
+	Key  string
+	Want string
+	Got  string
+}
+
+/*
+ * Constants and Package Scope Variables
+ */
+
+var (
+	// ErrKeyNotFound is returned for keys without a declared option.
+	ErrKeyNotFound = errors.New("Key is not found")
+	// ErrRequiredMissing is returned when a required option isn't set.
+	ErrRequiredMissing = errors.New("Required option is not provided")
+	// ErrDuplicate is returned when an option is set twice by one source.
+	ErrDuplicate = errors.New("Duplicate definition")
+	// ErrNoValue is returned when neither a value nor a default value of an
+	// option is set.
+	ErrNoValue = errors.New("No value and no default value are set")
+)
+
+/*
+ * Package Private Functions
+ */
+
+/*
+ * Public Functions
+ */
+
+func (err *TypeMismatchError) Error() string {
+	return fmt.Sprintf("Value of option \"%v\" is not %v. Its type is %v.", err.Key, err.Want, err.Got)
+}
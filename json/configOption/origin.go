@@ -0,0 +1,84 @@
+package configOption
+
+/*
+ * Module Dependencies
+ */
+
+import (
+	"fmt"
+)
+
+/*
+ * Types
+ */
+
+// Origin is where the value of an option came from. Name is the file path,
+// the environment variable or the flag name, and Line and Column are the
+// position of the key in the file, if known.
+type Origin struct {
+	Kind   string
+	Name   string
+	Line   int
+	Column int
+}
+
+/*
+ * Constants and Package Scope Variables
+ */
+
+// Kinds of Origin.
+const (
+	ORIGIN_DEFAULT    string = "default"
+	ORIGIN_ENV        string = "env"
+	ORIGIN_FILE       string = "file"
+	ORIGIN_FLAG       string = "flag"
+	ORIGIN_KEY_VALUES string = "keyValues"
+	ORIGIN_SET        string = "set"
+)
+
+/*
+ * Package Private Functions
+ */
+
+/*
+ * Public Functions
+ */
+
+func (origin Origin) String() string {
+	switch origin.Kind {
+	case ORIGIN_DEFAULT:
+		return "default value"
+	case ORIGIN_ENV:
+		return "environment variable " + origin.Name
+	case ORIGIN_FILE:
+		name := origin.Name
+		if name == "" {
+			name = "<bytes>"
+		}
+		if origin.Line > 0 {
+			return fmt.Sprintf("file %v:%v:%v", name, origin.Line, origin.Column)
+		}
+		return "file " + name
+	case ORIGIN_FLAG:
+		return "flag -" + origin.Name
+	case ORIGIN_KEY_VALUES:
+		return "key values"
+	case ORIGIN_SET:
+		return "SetValue"
+	}
+	return "unknown origin"
+}
+
+// SetValueFrom sets value as SetValue does, recording origin as its Origin.
+// The Origin of the value it replaces is appended to Overridden.
+func (opt *Option) SetValueFrom(value interface{}, origin Origin) error {
+	if err := opt.setValue(value); err != nil {
+		return err
+	}
+	if opt.Origin.Kind != "" {
+		// Don't share the backing array with copies of opt.
+		opt.Overridden = append(opt.Overridden[:len(opt.Overridden):len(opt.Overridden)], opt.Origin)
+	}
+	opt.Origin = origin
+	return nil
+}
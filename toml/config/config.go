@@ -0,0 +1,86 @@
+package config
+
+/*
+ * Module Dependencies
+ */
+
+import (
+	"github.com/BurntSushi/toml"
+	jsonConfig "github.com/mozzzzy/config/json/config"
+)
+
+/*
+ * Types
+ */
+
+// Config accepts the same configOption.Option declarations as json/config
+// and reads them from TOML documents.
+// Importing this package also registers the "toml" format, so that
+// jsonConfig.Config.Parse can read .toml files.
+// TOML integers are set to int and int64 options without going through
+// float64, and datetimes are set to time options.
+type Config struct {
+	jsonConfig.Config
+}
+
+/*
+ * Constants and Package Scope Variables
+ */
+
+const FORMAT string = "toml"
+
+/*
+ * Package Private Functions
+ */
+
+func init() {
+	jsonConfig.RegisterDecoder(FORMAT, jsonConfig.DecoderFunc(Decode), ".toml")
+}
+
+// normalize converts values decoded by toml into the shape accepted by
+// jsonConfig.Config.ParseKeyValues.
+func normalize(value interface{}) interface{} {
+	switch val := value.(type) {
+	case []interface{}:
+		ary := make([]interface{}, len(val))
+		for index, elem := range val {
+			ary[index] = normalize(elem)
+		}
+		return ary
+	case []map[string]interface{}:
+		ary := make([]interface{}, len(val))
+		for index, elem := range val {
+			ary[index] = normalizeMap(elem)
+		}
+		return ary
+	case map[string]interface{}:
+		return normalizeMap(val)
+	}
+	return value
+}
+
+func normalizeMap(kvs map[string]interface{}) map[string]interface{} {
+	normalizedKvs := make(map[string]interface{})
+	for key, value := range kvs {
+		normalizedKvs[key] = normalize(value)
+	}
+	return normalizedKvs
+}
+
+/*
+ * Public Functions
+ */
+
+// Decode decodes a TOML document into key values accepted by
+// jsonConfig.Config.ParseKeyValues.
+func Decode(raw []byte) (map[string]interface{}, error) {
+	keyValues := make(map[string]interface{})
+	if unmarshalErr := toml.Unmarshal(raw, &(keyValues)); unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+	return normalizeMap(keyValues), nil
+}
+
+func (conf *Config) Parse(path string) error {
+	return conf.ParseFormat(path, FORMAT)
+}
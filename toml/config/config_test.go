@@ -0,0 +1,203 @@
+package config
+
+/*
+ * Module Dependencies
+ */
+
+import (
+	"testing"
+	"time"
+
+	jsonConfig "github.com/mozzzzy/config/json/config"
+	"github.com/mozzzzy/config/json/configOption"
+	"github.com/mozzzzy/testUtil"
+)
+
+/*
+ * Types
+ */
+
+/*
+ * Constants and Package Scope Variables
+ */
+
+const ALL_IN_ONE_TOML string = "testData/all_in_one.toml"
+const ARRAY_OF_TABLES_TOML string = "testData/array_of_tables.toml"
+const ONE_STRING_TOML string = "testData/one_string.toml"
+
+/*
+ * Functions
+ */
+
+func TestParse(t *testing.T) {
+	t.Run("valid multi", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions([]configOption.Option{
+			{
+				Key:         "array",
+				ValueType:   "array",
+				Description: "some description.",
+			},
+			{
+				Key:         "float64",
+				ValueType:   "float64",
+				Description: "some description.",
+			},
+			{
+				Key:         "int",
+				ValueType:   "int",
+				Description: "some description.",
+			},
+			{
+				Key:         "int64",
+				ValueType:   "int64",
+				Description: "some description.",
+			},
+			{
+				Key:         "string",
+				ValueType:   "string",
+				Description: "some description.",
+			},
+			{
+				Key:         "time",
+				ValueType:   "time",
+				Description: "some description.",
+			},
+			{
+				Key:         "object",
+				ValueType:   "object",
+				Description: "some description.",
+			},
+			{
+				Key:         "object.array",
+				ValueType:   "array",
+				Description: "some description.",
+			},
+			{
+				Key:         "object.float64",
+				ValueType:   "float64",
+				Description: "some description.",
+			},
+			{
+				Key:         "object.int",
+				ValueType:   "int",
+				Description: "some description.",
+			},
+			{
+				Key:         "object.string",
+				ValueType:   "string",
+				Description: "some description.",
+			},
+			{
+				Key:         "object.time",
+				ValueType:   "time",
+				Description: "some description.",
+			},
+		})
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(ALL_IN_ONE_TOML)
+		testUtil.NoError(t, parseErr)
+
+		actualAry, getAryErr := conf.GetStringArray("array")
+		testUtil.NoError(t, getAryErr)
+		testUtil.Match(t, []string{"some", "value"}, actualAry)
+
+		actualFlt64, getFlt64Err := conf.GetFloat64("float64")
+		testUtil.NoError(t, getFlt64Err)
+		testUtil.Match(t, 1.2, actualFlt64)
+
+		actualInt, getIntErr := conf.GetInt("int")
+		testUtil.NoError(t, getIntErr)
+		testUtil.Match(t, 10, actualInt)
+
+		// 2^53 + 1 can't be represented by float64.
+		actualInt64, getInt64Err := conf.GetInt64("int64")
+		testUtil.NoError(t, getInt64Err)
+		testUtil.Match(t, int64(9007199254740993), actualInt64)
+
+		actualStr, getStrErr := conf.GetString("string")
+		testUtil.NoError(t, getStrErr)
+		testUtil.Match(t, "some value", actualStr)
+
+		actualTime, getTimeErr := conf.GetTime("time")
+		testUtil.NoError(t, getTimeErr)
+		testUtil.Match(t, true, actualTime.Equal(time.Date(2020, 3, 8, 12, 34, 56, 0, time.UTC)))
+
+		actualObjAry, getObjAryErr := conf.GetIntArray("object.array")
+		testUtil.NoError(t, getObjAryErr)
+		testUtil.Match(t, []int{1, 2, 3}, actualObjAry)
+
+		actualObjInt, getObjIntErr := conf.GetInt("object.int")
+		testUtil.NoError(t, getObjIntErr)
+		testUtil.Match(t, 20, actualObjInt)
+
+		actualObjTime, getObjTimeErr := conf.GetTime("object.time")
+		testUtil.NoError(t, getObjTimeErr)
+		testUtil.Match(t, "2020-03-08", actualObjTime.Format("2006-01-02"))
+	})
+
+	t.Run("valid array of tables", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "servers",
+				ValueType:   "array",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(ARRAY_OF_TABLES_TOML)
+		testUtil.NoError(t, parseErr)
+
+		actual, getErr := conf.Get("servers")
+		testUtil.NoError(t, getErr)
+
+		castedActual, ok := actual.([]interface{})
+		testUtil.Match(t, true, ok)
+		testUtil.Match(t, 2, len(castedActual))
+	})
+
+	t.Run("invalid (type is int <-> value is string)", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "string",
+				ValueType:   "int", // this should be string
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(ONE_STRING_TOML)
+		testUtil.WithError(t, parseErr)
+	})
+
+	t.Run("invalid (file does not exist)", func(t *testing.T) {
+		var conf Config
+		parseErr := conf.Parse("testData/not_exist.toml")
+		testUtil.WithError(t, parseErr)
+	})
+}
+
+func TestDecode(t *testing.T) {
+	t.Run("parse with json/config", func(t *testing.T) {
+		var conf jsonConfig.Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "string",
+				ValueType:   "string",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(ONE_STRING_TOML)
+		testUtil.NoError(t, parseErr)
+
+		actual, getErr := conf.GetString("string")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, "some value", actual)
+	})
+}
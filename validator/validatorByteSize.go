@@ -0,0 +1,73 @@
+package validator
+
+/*
+ * Module Dependencies
+ */
+
+import (
+	"errors"
+	"fmt"
+)
+
+/*
+ * Types
+ */
+
+/*
+ * Constants and Package Scope Variables
+ */
+
+/*
+ * Functions
+ */
+func ByteSizeBiggerThan(val, min interface{}) error {
+	bytesVal, bytesValOk := val.(int64)
+	if !bytesValOk {
+		return errors.New(fmt.Sprintf("Specified value %v is not bytesize (int64) type.", val))
+	}
+	bytesMin, bytesMinOk := min.(int64)
+	if !bytesMinOk {
+		return errors.New(fmt.Sprintf("Specified mininum %v is not bytesize (int64) type.", min))
+	}
+	if bytesVal < bytesMin {
+		return errors.New(fmt.Sprintf("%v < %v.", bytesVal, bytesMin))
+	}
+	return nil
+}
+
+func ByteSizeSmallerThan(val, max interface{}) error {
+	bytesVal, bytesValOk := val.(int64)
+	if !bytesValOk {
+		return errors.New(fmt.Sprintf("Specified value %v is not bytesize (int64) type.", val))
+	}
+	bytesMax, bytesMaxOk := max.(int64)
+	if !bytesMaxOk {
+		return errors.New(fmt.Sprintf("Specified max %v is not bytesize (int64) type.", max))
+	}
+	if bytesVal > bytesMax {
+		return errors.New(fmt.Sprintf("%v > %v.", bytesVal, bytesMax))
+	}
+	return nil
+}
+
+func ByteSizeWithin(val interface{}, minMax interface{}) error {
+	bytesVal, bytesValOk := val.(int64)
+	if !bytesValOk {
+		return errors.New(fmt.Sprintf("Specified value %v is not bytesize (int64) type.", val))
+	}
+	bytesAryMinMax, bytesAryMinMaxOk := minMax.([]int64)
+	if !bytesAryMinMaxOk {
+		return errors.New(
+			fmt.Sprintf("Specified value %v should be an array of min and max.", minMax))
+	}
+	if len(bytesAryMinMax) < 2 {
+		return errors.New(fmt.Sprintf("Can't get min or max value from %v.", bytesAryMinMax))
+	}
+	if BiggerErr := ByteSizeBiggerThan(bytesVal, bytesAryMinMax[0]); BiggerErr != nil {
+		return BiggerErr
+	}
+	if SmallerErr := ByteSizeSmallerThan(bytesVal, bytesAryMinMax[1]); SmallerErr != nil {
+		return SmallerErr
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package validator
+
+/*
+ * Module Dependencies
+ */
+
+import (
+	"testing"
+
+	"github.com/mozzzzy/testUtil"
+)
+
+/*
+ * Types
+ */
+
+/*
+ * Constants and Package Scope Variables
+ */
+
+/*
+ * Functions
+ */
+
+func TestByteSizeBiggerThan(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		err := ByteSizeBiggerThan(int64(1024), int64(1024))
+		testUtil.NoError(t, err)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		err := ByteSizeBiggerThan(int64(1024), int64(1025))
+		testUtil.WithError(t, err)
+	})
+}
+
+func TestByteSizeSmallerThan(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		err := ByteSizeSmallerThan(int64(1024), int64(1024))
+		testUtil.NoError(t, err)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		err := ByteSizeSmallerThan(int64(1024), int64(1023))
+		testUtil.WithError(t, err)
+	})
+}
+
+func TestByteSizeWithin(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		err := ByteSizeWithin(int64(1024), []int64{1000, 1024})
+		testUtil.NoError(t, err)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		err := ByteSizeWithin(int64(1024), []int64{1000, 1023})
+		testUtil.WithError(t, err)
+	})
+}
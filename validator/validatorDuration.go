@@ -0,0 +1,74 @@
+package validator
+
+/*
+ * Module Dependencies
+ */
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+/*
+ * Types
+ */
+
+/*
+ * Constants and Package Scope Variables
+ */
+
+/*
+ * Functions
+ */
+func DurationBiggerThan(val, min interface{}) error {
+	durationVal, durationValOk := val.(time.Duration)
+	if !durationValOk {
+		return errors.New(fmt.Sprintf("Specified value %v is not time.Duration type.", val))
+	}
+	durationMin, durationMinOk := min.(time.Duration)
+	if !durationMinOk {
+		return errors.New(fmt.Sprintf("Specified mininum %v is not time.Duration type.", min))
+	}
+	if durationVal < durationMin {
+		return errors.New(fmt.Sprintf("%v < %v.", durationVal, durationMin))
+	}
+	return nil
+}
+
+func DurationSmallerThan(val, max interface{}) error {
+	durationVal, durationValOk := val.(time.Duration)
+	if !durationValOk {
+		return errors.New(fmt.Sprintf("Specified value %v is not time.Duration type.", val))
+	}
+	durationMax, durationMaxOk := max.(time.Duration)
+	if !durationMaxOk {
+		return errors.New(fmt.Sprintf("Specified max %v is not time.Duration type.", max))
+	}
+	if durationVal > durationMax {
+		return errors.New(fmt.Sprintf("%v > %v.", durationVal, durationMax))
+	}
+	return nil
+}
+
+func DurationWithin(val interface{}, minMax interface{}) error {
+	durationVal, durationValOk := val.(time.Duration)
+	if !durationValOk {
+		return errors.New(fmt.Sprintf("Specified value %v is not time.Duration type.", val))
+	}
+	durationAryMinMax, durationAryMinMaxOk := minMax.([]time.Duration)
+	if !durationAryMinMaxOk {
+		return errors.New(
+			fmt.Sprintf("Specified value %v should be an array of min and max.", minMax))
+	}
+	if len(durationAryMinMax) < 2 {
+		return errors.New(fmt.Sprintf("Can't get min or max value from %v.", durationAryMinMax))
+	}
+	if BiggerErr := DurationBiggerThan(durationVal, durationAryMinMax[0]); BiggerErr != nil {
+		return BiggerErr
+	}
+	if SmallerErr := DurationSmallerThan(durationVal, durationAryMinMax[1]); SmallerErr != nil {
+		return SmallerErr
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package validator
+
+/*
+ * Module Dependencies
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mozzzzy/testUtil"
+)
+
+/*
+ * Types
+ */
+
+/*
+ * Constants and Package Scope Variables
+ */
+
+/*
+ * Functions
+ */
+
+func TestDurationBiggerThan(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		err := DurationBiggerThan(time.Second, time.Second)
+		testUtil.NoError(t, err)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		err := DurationBiggerThan(time.Second, time.Minute)
+		testUtil.WithError(t, err)
+	})
+}
+
+func TestDurationSmallerThan(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		err := DurationSmallerThan(time.Second, time.Second)
+		testUtil.NoError(t, err)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		err := DurationSmallerThan(time.Minute, time.Second)
+		testUtil.WithError(t, err)
+	})
+}
+
+func TestDurationWithin(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		err := DurationWithin(time.Second, []time.Duration{time.Millisecond, time.Second})
+		testUtil.NoError(t, err)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		err := DurationWithin(time.Minute, []time.Duration{time.Millisecond, time.Second})
+		testUtil.WithError(t, err)
+	})
+}
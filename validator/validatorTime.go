@@ -0,0 +1,74 @@
+package validator
+
+/*
+ * Module Dependencies
+ */
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+/*
+ * Types
+ */
+
+/*
+ * Constants and Package Scope Variables
+ */
+
+/*
+ * Functions
+ */
+func TimeAfter(val, min interface{}) error {
+	timeVal, timeValOk := val.(time.Time)
+	if !timeValOk {
+		return errors.New(fmt.Sprintf("Specified value %v is not time.Time type.", val))
+	}
+	timeMin, timeMinOk := min.(time.Time)
+	if !timeMinOk {
+		return errors.New(fmt.Sprintf("Specified mininum %v is not time.Time type.", min))
+	}
+	if timeVal.Before(timeMin) {
+		return errors.New(fmt.Sprintf("%v is before %v.", timeVal, timeMin))
+	}
+	return nil
+}
+
+func TimeBefore(val, max interface{}) error {
+	timeVal, timeValOk := val.(time.Time)
+	if !timeValOk {
+		return errors.New(fmt.Sprintf("Specified value %v is not time.Time type.", val))
+	}
+	timeMax, timeMaxOk := max.(time.Time)
+	if !timeMaxOk {
+		return errors.New(fmt.Sprintf("Specified max %v is not time.Time type.", max))
+	}
+	if timeVal.After(timeMax) {
+		return errors.New(fmt.Sprintf("%v is after %v.", timeVal, timeMax))
+	}
+	return nil
+}
+
+func TimeWithin(val interface{}, minMax interface{}) error {
+	timeVal, timeValOk := val.(time.Time)
+	if !timeValOk {
+		return errors.New(fmt.Sprintf("Specified value %v is not time.Time type.", val))
+	}
+	timeAryMinMax, timeAryMinMaxOk := minMax.([]time.Time)
+	if !timeAryMinMaxOk {
+		return errors.New(
+			fmt.Sprintf("Specified value %v should be an array of min and max.", minMax))
+	}
+	if len(timeAryMinMax) < 2 {
+		return errors.New(fmt.Sprintf("Can't get min or max value from %v.", timeAryMinMax))
+	}
+	if AfterErr := TimeAfter(timeVal, timeAryMinMax[0]); AfterErr != nil {
+		return AfterErr
+	}
+	if BeforeErr := TimeBefore(timeVal, timeAryMinMax[1]); BeforeErr != nil {
+		return BeforeErr
+	}
+	return nil
+}
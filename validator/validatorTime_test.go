@@ -0,0 +1,66 @@
+package validator
+
+/*
+ * Module Dependencies
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mozzzzy/testUtil"
+)
+
+/*
+ * Types
+ */
+
+/*
+ * Constants and Package Scope Variables
+ */
+
+/*
+ * Functions
+ */
+
+func TestTimeAfter(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		tm := time.Date(2020, 3, 8, 0, 0, 0, 0, time.UTC)
+		err := TimeAfter(tm, tm)
+		testUtil.NoError(t, err)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		tm := time.Date(2020, 3, 8, 0, 0, 0, 0, time.UTC)
+		err := TimeAfter(tm, tm.Add(time.Second))
+		testUtil.WithError(t, err)
+	})
+}
+
+func TestTimeBefore(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		tm := time.Date(2020, 3, 8, 0, 0, 0, 0, time.UTC)
+		err := TimeBefore(tm, tm)
+		testUtil.NoError(t, err)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		tm := time.Date(2020, 3, 8, 0, 0, 0, 0, time.UTC)
+		err := TimeBefore(tm, tm.Add(-time.Second))
+		testUtil.WithError(t, err)
+	})
+}
+
+func TestTimeWithin(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		tm := time.Date(2020, 3, 8, 0, 0, 0, 0, time.UTC)
+		err := TimeWithin(tm, []time.Time{tm.Add(-time.Hour), tm.Add(time.Hour)})
+		testUtil.NoError(t, err)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		tm := time.Date(2020, 3, 8, 0, 0, 0, 0, time.UTC)
+		err := TimeWithin(tm, []time.Time{tm.Add(time.Hour), tm.Add(2 * time.Hour)})
+		testUtil.WithError(t, err)
+	})
+}
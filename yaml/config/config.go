@@ -0,0 +1,102 @@
+package config
+
+/*
+ * Module Dependencies
+ */
+
+import (
+	"errors"
+	"fmt"
+
+	jsonConfig "github.com/mozzzzy/config/json/config"
+	"gopkg.in/yaml.v3"
+)
+
+/*
+ * Types
+ */
+
+// Config accepts the same configOption.Option declarations as json/config
+// and reads them from YAML documents.
+// Importing this package also registers the "yaml" format, so that
+// jsonConfig.Config.Parse can read .yaml and .yml files.
+type Config struct {
+	jsonConfig.Config
+}
+
+/*
+ * Constants and Package Scope Variables
+ */
+
+const FORMAT string = "yaml"
+
+/*
+ * Package Private Functions
+ */
+
+func init() {
+	jsonConfig.RegisterDecoder(FORMAT, jsonConfig.DecoderFunc(Decode), ".yaml", ".yml")
+}
+
+// normalize converts values decoded by yaml into the shape accepted by
+// jsonConfig.Config.ParseKeyValues. Integers are kept as int64 (or uint64),
+// so that int64 options don't lose precision.
+func normalize(value interface{}) (interface{}, error) {
+	switch val := value.(type) {
+	case int:
+		return int64(val), nil
+	case []interface{}:
+		ary := make([]interface{}, len(val))
+		for index, elem := range val {
+			normalized, err := normalize(elem)
+			if err != nil {
+				return nil, err
+			}
+			ary[index] = normalized
+		}
+		return ary, nil
+	case map[string]interface{}:
+		return normalizeMap(val)
+	case map[interface{}]interface{}:
+		kvs := make(map[string]interface{})
+		for key, elem := range val {
+			str, ok := key.(string)
+			if !ok {
+				return nil, errors.New(fmt.Sprintf("Invalid mapping key \"%v\". Its type is %T.", key, key))
+			}
+			kvs[str] = elem
+		}
+		return normalizeMap(kvs)
+	}
+	return value, nil
+}
+
+func normalizeMap(kvs map[string]interface{}) (map[string]interface{}, error) {
+	normalizedKvs := make(map[string]interface{})
+	for key, value := range kvs {
+		normalized, err := normalize(value)
+		if err != nil {
+			return nil, err
+		}
+		normalizedKvs[key] = normalized
+	}
+	return normalizedKvs, nil
+}
+
+/*
+ * Public Functions
+ */
+
+// Decode decodes a YAML document into key values accepted by
+// jsonConfig.Config.ParseKeyValues.
+func Decode(raw []byte) (map[string]interface{}, error) {
+	keyValues := make(map[string]interface{})
+	if unmarshalErr := yaml.Unmarshal(raw, &(keyValues)); unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+	return normalizeMap(keyValues)
+}
+
+func (conf *Config) Parse(path string) error {
+	return conf.ParseFormat(path, FORMAT)
+}
@@ -0,0 +1,224 @@
+package config
+
+/*
+ * Module Dependencies
+ */
+
+import (
+	"testing"
+
+	jsonConfig "github.com/mozzzzy/config/json/config"
+	"github.com/mozzzzy/config/json/configOption"
+	"github.com/mozzzzy/testUtil"
+)
+
+/*
+ * Types
+ */
+
+/*
+ * Constants and Package Scope Variables
+ */
+
+const ALL_IN_ONE_YAML string = "testData/all_in_one.yaml"
+const BIG_INT64_YAML string = "testData/big_int64.yaml"
+const NON_STRING_KEY_YAML string = "testData/non_string_key.yaml"
+const ONE_INT_YAML string = "testData/one_int.yaml"
+const ONE_OBJECT_YAML string = "testData/one_object.yaml"
+
+/*
+ * Functions
+ */
+
+func TestParse(t *testing.T) {
+	t.Run("valid multi", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions([]configOption.Option{
+			{
+				Key:         "array",
+				ValueType:   "array",
+				Description: "some description.",
+			},
+			{
+				Key:         "float64",
+				ValueType:   "float64",
+				Description: "some description.",
+			},
+			{
+				Key:         "int",
+				ValueType:   "int",
+				Description: "some description.",
+			},
+			{
+				Key:         "int64",
+				ValueType:   "int64",
+				Description: "some description.",
+			},
+			{
+				Key:         "string",
+				ValueType:   "string",
+				Description: "some description.",
+			},
+			{
+				Key:         "object",
+				ValueType:   "object",
+				Description: "some description.",
+			},
+			{
+				Key:         "object.array",
+				ValueType:   "array",
+				Description: "some description.",
+			},
+			{
+				Key:         "object.float64",
+				ValueType:   "float64",
+				Description: "some description.",
+			},
+			{
+				Key:         "object.int",
+				ValueType:   "int",
+				Description: "some description.",
+			},
+			{
+				Key:         "object.string",
+				ValueType:   "string",
+				Description: "some description.",
+			},
+		})
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(ALL_IN_ONE_YAML)
+		testUtil.NoError(t, parseErr)
+
+		actualAry, getAryErr := conf.GetStringArray("array")
+		testUtil.NoError(t, getAryErr)
+		testUtil.Match(t, []string{"some", "value"}, actualAry)
+
+		actualFlt64, getFlt64Err := conf.GetFloat64("float64")
+		testUtil.NoError(t, getFlt64Err)
+		testUtil.Match(t, 1.2, actualFlt64)
+
+		actualInt, getIntErr := conf.GetInt("int")
+		testUtil.NoError(t, getIntErr)
+		testUtil.Match(t, 10, actualInt)
+
+		actualInt64, getInt64Err := conf.GetInt64("int64")
+		testUtil.NoError(t, getInt64Err)
+		testUtil.Match(t, int64(2147483648), actualInt64)
+
+		actualStr, getStrErr := conf.GetString("string")
+		testUtil.NoError(t, getStrErr)
+		testUtil.Match(t, "some value", actualStr)
+
+		actualObjInt, getObjIntErr := conf.GetInt("object.int")
+		testUtil.NoError(t, getObjIntErr)
+		testUtil.Match(t, 20, actualObjInt)
+
+		actualObjStr, getObjStrErr := conf.GetString("object.string")
+		testUtil.NoError(t, getObjStrErr)
+		testUtil.Match(t, "some value in object", actualObjStr)
+	})
+
+	t.Run("valid object", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOptions([]configOption.Option{
+			{
+				Key:         "object",
+				ValueType:   "object",
+				Description: "some description.",
+			},
+			{
+				Key:         "object.key",
+				ValueType:   "string",
+				Description: "some description.",
+			},
+		})
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(ONE_OBJECT_YAML)
+		testUtil.NoError(t, parseErr)
+
+		childConf, getObjectErr := conf.GetObject("object")
+		testUtil.NoError(t, getObjectErr)
+
+		actual, getStringErr := childConf.GetString("key")
+		testUtil.NoError(t, getStringErr)
+		testUtil.Match(t, "value", actual)
+	})
+
+	t.Run("valid int64 bigger than 2^53", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "int64",
+				ValueType:   "int64",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(BIG_INT64_YAML)
+		testUtil.NoError(t, parseErr)
+
+		actual, getErr := conf.GetInt64("int64")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, int64(9007199254740993), actual)
+	})
+
+	t.Run("invalid (type is string <-> value is int)", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "int",
+				ValueType:   "string",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(ONE_INT_YAML)
+		testUtil.WithError(t, parseErr)
+	})
+
+	t.Run("invalid (mapping key is not string)", func(t *testing.T) {
+		var conf Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "object",
+				ValueType:   "object",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(NON_STRING_KEY_YAML)
+		testUtil.WithError(t, parseErr)
+	})
+
+	t.Run("invalid (file does not exist)", func(t *testing.T) {
+		var conf Config
+		parseErr := conf.Parse("testData/not_exist.yaml")
+		testUtil.WithError(t, parseErr)
+	})
+}
+
+func TestDecode(t *testing.T) {
+	t.Run("parse with json/config", func(t *testing.T) {
+		var conf jsonConfig.Config
+		addOptionErr := conf.AddOption(
+			configOption.Option{
+				Key:         "int",
+				ValueType:   "int",
+				Description: "some description.",
+			},
+		)
+		testUtil.NoError(t, addOptionErr)
+
+		parseErr := conf.Parse(ONE_INT_YAML)
+		testUtil.NoError(t, parseErr)
+
+		actual, getErr := conf.GetInt("int")
+		testUtil.NoError(t, getErr)
+		testUtil.Match(t, 10, actual)
+	})
+}